@@ -0,0 +1,64 @@
+package uuid
+
+import (
+	"encoding/binary"
+	"fmt"
+)
+
+// Bytes returns a copy of the 16 bytes of the uuid, so modifying the
+// returned slice doesn't modify the uuid.
+func (id UUID) Bytes() []byte {
+	b := make([]byte, len(id))
+	copy(b, id[:])
+	return b
+}
+
+// FromBytes returns the uuid made of a copy of the given 16 bytes, as is,
+// without validating the version or the variant. An error wrapping
+// ErrLength is returned if b is not 16 bytes long.
+func FromBytes(b []byte) (UUID, error) {
+	var id UUID
+	if len(b) != len(id) {
+		return Nil, fmt.Errorf("%w %d, expected 16", ErrLength, len(b))
+	}
+
+	copy(id[:], b)
+	return id, nil
+}
+
+// Words returns the uuid as four big-endian 32-bit words, as transmitted by
+// some network protocols.
+func (id UUID) Words() [4]uint32 {
+	var w [4]uint32
+	for i := range w {
+		w[i] = binary.BigEndian.Uint32(id[i*4:])
+	}
+	return w
+}
+
+// FromWords returns the uuid made of four big-endian 32-bit words, it's the
+// inverse of UUID.Words.
+func FromWords(w [4]uint32) UUID {
+	var id UUID
+	for i, word := range w {
+		binary.BigEndian.PutUint32(id[i*4:], word)
+	}
+	return id
+}
+
+// TaggedBytes returns a fixed-length binary record made of the given type
+// tag followed by the 16 bytes of the uuid.
+func (id UUID) TaggedBytes(tag byte) [17]byte {
+	var b [17]byte
+	b[0] = tag
+	copy(b[1:], id[:])
+	return b
+}
+
+// FromTaggedBytes returns the type tag and the uuid of a record built by
+// UUID.TaggedBytes.
+func FromTaggedBytes(b [17]byte) (byte, UUID) {
+	var id UUID
+	copy(id[:], b[1:])
+	return b[0], id
+}
@@ -0,0 +1,95 @@
+package uuid
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+)
+
+func TestUUID_Bytes(t *testing.T) {
+	uid := must(t, func() (UUID, error) { return Parse(StaticUUID) })
+	b := uid.Bytes()
+	want := []byte{0x00, 0x01, 0x02, 0x03, 0x04, 0x05, 0x46, 0x07, 0x88, 0x09, 0x0a, 0x0b, 0x0c, 0x0d, 0x0e, 0x0f}
+	if !bytes.Equal(b, want) {
+		t.Fatalf("unexpected bytes: %#x", b)
+	}
+
+	// the returned slice is a copy.
+	b[0] = 0xff
+	if uid.String() != StaticUUID {
+		t.Fatal("unexpected modified uuid:", uid)
+	}
+
+	got, err := FromBytes(want)
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	if got != uid {
+		t.Fatal("unexpected uuid:", got)
+	}
+
+	// the input is copied, and not validated.
+	want[0] = 0xff
+	if got.String() != StaticUUID {
+		t.Fatal("unexpected modified uuid:", got)
+	}
+
+	if got, err := FromBytes(Max.Bytes()); err != nil || got != Max {
+		t.Fatal("unexpected uuid:", got, err)
+	}
+}
+
+func TestFromBytes_Errors(t *testing.T) {
+	for _, b := range [][]byte{nil, make([]byte, 15), make([]byte, 17)} {
+		uid, err := FromBytes(b)
+		if !errors.Is(err, ErrLength) {
+			t.Fatal("unexpected error:", err)
+		}
+
+		if uid != Nil {
+			t.Fatal("unexpected nil uuid:", uid)
+		}
+	}
+}
+
+func TestUUID_Words(t *testing.T) {
+	uid := must(t, func() (UUID, error) { return Parse(StaticUUID) })
+	want := [4]uint32{0x00010203, 0x04054607, 0x88090a0b, 0x0c0d0e0f}
+	if got := uid.Words(); got != want {
+		t.Fatalf("unexpected words: %#x", got)
+	}
+
+	if got := FromWords(want); got != uid {
+		t.Fatal("unexpected uuid:", got)
+	}
+
+	// takes 100 random samples.
+	for i := 0; i < 100; i++ {
+		uid = must(t, New)
+		if got := FromWords(uid.Words()); got != uid {
+			t.Fatal("unexpected uuid:", got)
+		}
+	}
+}
+
+func TestUUID_TaggedBytes(t *testing.T) {
+	uid := must(t, func() (UUID, error) { return Parse(StaticUUID) })
+	b := uid.TaggedBytes(0x2a)
+	want := [17]byte{0x2a, 0x00, 0x01, 0x02, 0x03, 0x04, 0x05, 0x46, 0x07, 0x88, 0x09, 0x0a, 0x0b, 0x0c, 0x0d, 0x0e, 0x0f}
+	if b != want {
+		t.Fatalf("unexpected record: %#x", b)
+	}
+
+	for _, tag := range []byte{0x00, 0x01, 0x7f, 0xff} {
+		uid = must(t, New)
+		gotTag, got := FromTaggedBytes(uid.TaggedBytes(tag))
+		if gotTag != tag {
+			t.Fatalf("unexpected tag: %#x", gotTag)
+		}
+
+		if got != uid {
+			t.Fatal("unexpected uuid:", got)
+		}
+	}
+}
@@ -0,0 +1,45 @@
+package uuid
+
+import "errors"
+
+// ErrChecksum is returned when a checksummed uuid doesn't match its CRC-8.
+var ErrChecksum = errors.New("uuid: checksum mismatch")
+
+// AppendChecksum returns the 16 bytes of the uuid followed by their CRC-8
+// (polynomial 0x07), so the uuid can be validated after going through a
+// noisy transport. Any corrupted byte, including the checksum itself, is
+// detected by FromChecksummed.
+func (id UUID) AppendChecksum() [17]byte {
+	var b [17]byte
+	copy(b[:], id[:])
+	b[16] = crc8(id[:])
+	return b
+}
+
+// FromChecksummed returns the uuid of a record built by UUID.AppendChecksum,
+// or ErrChecksum if the record is corrupted.
+func FromChecksummed(b [17]byte) (UUID, error) {
+	if crc8(b[:16]) != b[16] {
+		return Nil, ErrChecksum
+	}
+
+	var uid UUID
+	copy(uid[:], b[:16])
+	return uid, nil
+}
+
+// crc8 computes the CRC-8 of b with the polynomial x^8 + x^2 + x + 1.
+func crc8(b []byte) byte {
+	var crc byte
+	for _, v := range b {
+		crc ^= v
+		for i := 0; i < 8; i++ {
+			if crc&0x80 != 0 {
+				crc = crc<<1 ^ 0x07
+			} else {
+				crc <<= 1
+			}
+		}
+	}
+	return crc
+}
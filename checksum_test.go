@@ -0,0 +1,51 @@
+package uuid
+
+import "testing"
+
+func TestUUID_AppendChecksum(t *testing.T) {
+	uid := must(t, New)
+	b := uid.AppendChecksum()
+
+	got, err := FromChecksummed(b)
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	if got != uid {
+		t.Fatal("unexpected uuid:", got)
+	}
+
+	// CRC-8/SMBUS check value.
+	if c := crc8([]byte("123456789")); c != 0xf4 {
+		t.Fatalf("unexpected crc: %#x", c)
+	}
+}
+
+func TestFromChecksummed_Errors(t *testing.T) {
+	uid := must(t, New)
+	b := uid.AppendChecksum()
+
+	// flips every bit of the data and of the checksum.
+	for i := range b {
+		for bit := 0; bit < 8; bit++ {
+			corrupted := b
+			corrupted[i] ^= 1 << bit
+
+			got, err := FromChecksummed(corrupted)
+			if err != ErrChecksum {
+				t.Fatalf("byte %d bit %d: unexpected error: %v", i, bit, err)
+			}
+
+			if got != Nil {
+				t.Fatal("unexpected nil uuid:", got)
+			}
+		}
+	}
+
+	// a whole corrupted byte.
+	corrupted := b
+	corrupted[3] ^= 0xff
+	if _, err := FromChecksummed(corrupted); err != ErrChecksum {
+		t.Fatal("unexpected error:", err)
+	}
+}
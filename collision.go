@@ -0,0 +1,40 @@
+package uuid
+
+import "math"
+
+// randomBits is the number of random bits of each version that may collide.
+// For the time-based versions it's the entropy within a single timestamp
+// tick: the 74 random bits of v7 within a millisecond, and the 14-bit clock
+// sequence plus the 47 random bits of a random node of v1 and v6 within a
+// 100-nanosecond interval. The name-based v3 and v5 collide as their hashes,
+// and v8 is assumed to fill all its non-reserved bits randomly.
+var randomBits = map[int]float64{
+	1: 61,
+	3: 122,
+	4: 122,
+	5: 122,
+	6: 61,
+	7: 74,
+	8: 122,
+}
+
+// CollisionProbability returns the birthday bound estimate of the
+// probability that at least two of count UUIDs of the given version collide.
+// For the time-based versions, count is the number of UUIDs generated within
+// the same timestamp tick, see randomBits. It returns NaN for an unknown
+// version, and 0 when count is less than 2.
+func CollisionProbability(version, count int) float64 {
+	bits, ok := randomBits[version]
+	if !ok {
+		return math.NaN()
+	}
+
+	if count < 2 {
+		return 0
+	}
+
+	// p = 1 - e^(-n(n-1) / 2N), computed with Expm1 to keep the precision of
+	// the tiny probabilities.
+	n := float64(count)
+	return -math.Expm1(-n * (n - 1) / (2 * math.Exp2(bits)))
+}
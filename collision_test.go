@@ -0,0 +1,53 @@
+package uuid
+
+import (
+	"math"
+	"testing"
+)
+
+func TestCollisionProbability(t *testing.T) {
+	table := []struct {
+		name    string
+		version int
+		count   int
+		want    float64
+	}{
+		{"v4 one", 4, 1, 0},
+		{"v4 two", 4, 2, 1.88e-37},
+		{"v4 one in a billion", 4, 103e12, 1e-9},
+		{"v4 half", 4, 2.71e18, 0.5},
+		{"v4 sqrt of space", 4, 1 << 61, 0.3935},
+		{"v7 one in a billion per millisecond", 7, 6.1e6, 1e-9},
+	}
+
+	for _, tt := range table {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			got := CollisionProbability(tt.version, tt.count)
+			if got < 0 || got > 1 {
+				t.Fatal("unexpected probability:", got)
+			}
+
+			// within 2% of the known approximation.
+			if math.Abs(got-tt.want) > tt.want*0.02 {
+				t.Fatalf("unexpected probability: %g, want %g", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCollisionProbability_Versions(t *testing.T) {
+	if v4, v7 := CollisionProbability(4, 1e6), CollisionProbability(7, 1e6); v7 <= v4 {
+		t.Fatalf("v7 should collide more than v4 within a window: %g <= %g", v7, v4)
+	}
+
+	if p := CollisionProbability(1, 1<<40); p != 1 {
+		t.Fatal("unexpected probability:", p)
+	}
+
+	for _, v := range []int{0, 2, 9} {
+		if p := CollisionProbability(v, 10); !math.IsNaN(p) {
+			t.Fatalf("unexpected probability for version %d: %g", v, p)
+		}
+	}
+}
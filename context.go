@@ -0,0 +1,61 @@
+package uuid
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/binary"
+	"sync/atomic"
+)
+
+// contextKey is the key of the span carried by a context.
+type contextKey struct{}
+
+// span is the trace identifier carried by a context. The children counter
+// is shared by all the contexts derived from the same span.
+type span struct {
+	id       UUID
+	children *uint64
+}
+
+// WithTraceID returns a copy of ctx carrying the given trace ID as the root
+// span. Use WithSpan to derive the IDs of the child spans.
+func WithTraceID(ctx context.Context, id UUID) context.Context {
+	return context.WithValue(ctx, contextKey{}, span{id: id, children: new(uint64)})
+}
+
+// WithSpan returns a copy of ctx carrying a new span ID deterministically
+// derived from the span ID of ctx and the number of spans already derived
+// from it. Since Go has no goroutine-local storage, calling WithSpan when
+// starting a goroutine gives it a stable ID for its lifetime. If ctx carries
+// no trace ID, it's returned unchanged.
+func WithSpan(ctx context.Context) context.Context {
+	parent, ok := ctx.Value(contextKey{}).(span)
+	if !ok {
+		return ctx
+	}
+
+	n := atomic.AddUint64(parent.children, 1)
+	child := span{id: deriveSpanID(parent.id, n), children: new(uint64)}
+	return context.WithValue(ctx, contextKey{}, child)
+}
+
+// GoroutineTraceID returns the span ID carried by ctx, which is the trace ID
+// given to WithTraceID or the one derived by WithSpan. The same context
+// always yields the same ID. It returns Nil if ctx carries no trace ID.
+func GoroutineTraceID(ctx context.Context) UUID {
+	s, _ := ctx.Value(contextKey{}).(span)
+	return s.id
+}
+
+// deriveSpanID derives the n-th child span ID of parent as a UUID v8 made of
+// the SHA-256 of the parent followed by the big-endian counter.
+func deriveSpanID(parent UUID, n uint64) UUID {
+	var buf [24]byte
+	copy(buf[:], parent[:])
+	binary.BigEndian.PutUint64(buf[16:], n)
+	sum := sha256.Sum256(buf[:])
+
+	var data [16]byte
+	copy(data[:], sum[:])
+	return NewV8(data)
+}
@@ -0,0 +1,59 @@
+package uuid
+
+import (
+	"context"
+	"testing"
+)
+
+func TestGoroutineTraceID(t *testing.T) {
+	if id := GoroutineTraceID(context.Background()); id != Nil {
+		t.Fatal("unexpected trace id:", id)
+	}
+
+	trace := must(t, New)
+	ctx := WithTraceID(context.Background(), trace)
+	if id := GoroutineTraceID(ctx); id != trace {
+		t.Fatal("unexpected trace id:", id)
+	}
+
+	child1 := WithSpan(ctx)
+	child2 := WithSpan(ctx)
+	id1, id2 := GoroutineTraceID(child1), GoroutineTraceID(child2)
+	if id1 == trace || id2 == trace || id1 == id2 {
+		t.Fatal("unexpected non distinct span ids:", trace, id1, id2)
+	}
+
+	if GoroutineTraceID(child1) != id1 {
+		t.Fatal("unexpected span id:", GoroutineTraceID(child1))
+	}
+
+	if id1[6]>>4 != 8 || id1[8]>>6 != 2 {
+		t.Fatal("unexpected version or variant:", id1)
+	}
+
+	grandchild := GoroutineTraceID(WithSpan(child1))
+	if grandchild == id1 || grandchild == id2 {
+		t.Fatal("unexpected non distinct span id:", grandchild)
+	}
+}
+
+func TestGoroutineTraceID_Deterministic(t *testing.T) {
+	trace := must(t, New)
+	ctx1 := WithTraceID(context.Background(), trace)
+	ctx2 := WithTraceID(context.Background(), trace)
+
+	// the same sequence of spans yields the same ids.
+	for i := 0; i < 3; i++ {
+		id1, id2 := GoroutineTraceID(WithSpan(ctx1)), GoroutineTraceID(WithSpan(ctx2))
+		if id1 != id2 {
+			t.Fatal("unexpected different span ids:", id1, id2)
+		}
+	}
+}
+
+func TestWithSpan_NoTraceID(t *testing.T) {
+	ctx := context.Background()
+	if WithSpan(ctx) != ctx {
+		t.Fatal("unexpected new context")
+	}
+}
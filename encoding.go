@@ -0,0 +1,409 @@
+package uuid
+
+import (
+	"encoding/base32"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"math/bits"
+	"strings"
+)
+
+// Encoding is a textual representation of a UUID.
+type Encoding int
+
+const (
+	// EncodingUnknown is an unrecognized representation.
+	EncodingUnknown Encoding = iota
+	// EncodingCanonical is xxxxxxxx-xxxx-xxxx-xxxx-xxxxxxxxxxxx.
+	EncodingCanonical
+	// EncodingHyphenless is 32 hex digits without dashes.
+	EncodingHyphenless
+	// EncodingBase32 is 26 characters of Crockford base32.
+	EncodingBase32
+	// EncodingBase64 is 22 characters of unpadded URL-safe base64.
+	EncodingBase64
+	// EncodingURN is urn:uuid:xxxxxxxx-xxxx-xxxx-xxxx-xxxxxxxxxxxx.
+	EncodingURN
+	// EncodingBraced is {xxxxxxxx-xxxx-xxxx-xxxx-xxxxxxxxxxxx}.
+	EncodingBraced
+)
+
+// String returns the name of the encoding.
+func (e Encoding) String() string {
+	switch e {
+	case EncodingCanonical:
+		return "canonical"
+	case EncodingHyphenless:
+		return "hyphenless"
+	case EncodingBase32:
+		return "base32"
+	case EncodingBase64:
+		return "base64"
+	case EncodingURN:
+		return "urn"
+	case EncodingBraced:
+		return "braced"
+	default:
+		return "unknown"
+	}
+}
+
+// crockfordAlphabet is the Crockford base32 alphabet, it excludes I, L, O
+// and U to avoid confusion.
+const crockfordAlphabet = "0123456789ABCDEFGHJKMNPQRSTVWXYZ"
+
+// crockfordEncoding encodes the 16 raw bytes of a UUID using the Crockford
+// base32 alphabet without padding.
+var crockfordEncoding = base32.NewEncoding(crockfordAlphabet).WithPadding(base32.NoPadding)
+
+// urnPrefix is the prefix of the URN form of a UUID as defined in RFC 4122.
+const urnPrefix = "urn:uuid:"
+
+// DetectEncoding returns the most likely encoding of s based on its length
+// and character set. It's a heuristic: the string is not fully validated,
+// so a detected encoding may still fail to decode. The returned bool is
+// false when no encoding matches.
+func DetectEncoding(s string) (Encoding, bool) {
+	switch {
+	case len(s) == 36 && isCanonical(s):
+		return EncodingCanonical, true
+	case len(s) == 32 && isAll(s, isHex):
+		return EncodingHyphenless, true
+	case len(s) == 38 && s[0] == '{' && s[37] == '}' && isCanonical(s[1:37]):
+		return EncodingBraced, true
+	case len(s) == 45 && strings.EqualFold(s[:9], urnPrefix) && isCanonical(s[9:]):
+		return EncodingURN, true
+	case len(s) == 26 && isAll(s, isBase32):
+		return EncodingBase32, true
+	case len(s) == 22 && isAll(s, isBase64):
+		return EncodingBase64, true
+	default:
+		return EncodingUnknown, false
+	}
+}
+
+// isCanonical reports whether s has hex digits and dashes at the positions
+// of the canonical form, s may be a prefix of the canonical form.
+func isCanonical(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if i == 8 || i == 13 || i == 18 || i == 23 {
+			if s[i] != '-' {
+				return false
+			}
+			continue
+		}
+
+		if !isHex(s[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// isAll reports whether every byte of s satisfies valid.
+func isAll(s string, valid func(c byte) bool) bool {
+	for i := 0; i < len(s); i++ {
+		if !valid(s[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+func isHex(c byte) bool { return hexValues[c] != 0xff }
+
+func isBase32(c byte) bool {
+	if c >= 'a' && c <= 'z' {
+		c -= 'a' - 'A'
+	}
+	return strings.IndexByte(crockfordAlphabet, c) >= 0
+}
+
+func isBase64(c byte) bool {
+	return (c >= '0' && c <= '9') || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || c == '-' || c == '_'
+}
+
+// Base32 returns the 16 bytes of the uuid as 26 characters of Crockford
+// base32, a compact and case insensitive form that avoids the ambiguous
+// letters I, L, O and U.
+func (id UUID) Base32() string {
+	return crockfordEncoding.EncodeToString(id[:])
+}
+
+// maxBase32Hyphens is the maximum number of hyphens accepted by
+// ParseBase32, enough to split the 26 symbols in readable groups while
+// keeping the string shorter than the 32 characters of the hyphenless form.
+const maxBase32Hyphens = 5
+
+// ParseBase32 parses a UUID from 26 characters of Crockford base32 as
+// returned by UUID.Base32, ignoring the letter case. Up to 5 hyphens, as
+// Crockford allows for readability, are ignored when they separate two
+// symbols, e.g. 000G40-R40N30-F20918-5GR38E1W. The 2 unused bits of the last
+// symbol must be zero, so each UUID has a single base32 form.
+func ParseBase32(s string) (UUID, error) {
+	if len(s) < 26 || len(s) > 26+maxBase32Hyphens {
+		return Nil, fmt.Errorf("uuid: incorrect base32 length: %s", s)
+	}
+
+	var digits [26]byte
+	n := 0
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if c == '-' {
+			if i == 0 || i == len(s)-1 || s[i-1] == '-' {
+				return Nil, fmt.Errorf("uuid: misplaced base32 hyphen: %s", s)
+			}
+			continue
+		}
+
+		if n == len(digits) {
+			return Nil, fmt.Errorf("uuid: incorrect base32 length: %s", s)
+		}
+
+		if c >= 'a' && c <= 'z' {
+			c -= 'a' - 'A'
+		}
+		digits[n] = c
+		n++
+	}
+
+	if n != len(digits) {
+		return Nil, fmt.Errorf("uuid: incorrect base32 length: %s", s)
+	}
+
+	if strings.IndexByte(crockfordAlphabet, digits[n-1])&0x03 != 0 {
+		return Nil, fmt.Errorf("uuid: invalid base32 string: %s", s)
+	}
+
+	var uid UUID
+	m, err := crockfordEncoding.Decode(uid[:], digits[:])
+	if err != nil || m != len(uid) {
+		return Nil, fmt.Errorf("uuid: invalid base32 string: %s", s)
+	}
+
+	return uid, nil
+}
+
+// NewToken generates a new UUID v4 with random generator rand.Reader and
+// returns it as 22 characters of unpadded URL-safe base64. It always
+// generates a v4, regardless of the default generator used by New.
+func NewToken() (string, error) {
+	uid, err := NewV4Generator(SecureReader).NewUUID()
+	if err != nil {
+		return "", err
+	}
+
+	return uid.Base64(), nil
+}
+
+// Base64 returns the 16 bytes of the uuid as 22 characters of unpadded
+// URL-safe base64, as used in JWT-style tokens.
+func (id UUID) Base64() string {
+	return base64.RawURLEncoding.EncodeToString(id[:])
+}
+
+// ParseBase64 parses a UUID from 22 characters of unpadded URL-safe base64
+// as returned by UUID.Base64. An error is returned if s doesn't decode to
+// exactly 16 bytes, or if the unused bits of its last character are not zero,
+// so each UUID has a single base64 form.
+func ParseBase64(s string) (UUID, error) {
+	if len(s) != 22 {
+		return Nil, fmt.Errorf("uuid: incorrect base64 length: %s", s)
+	}
+
+	var uid UUID
+	n, err := base64.RawURLEncoding.Strict().Decode(uid[:], []byte(s))
+	if err != nil || n != len(uid) {
+		return Nil, fmt.Errorf("uuid: invalid base64 string: %s", s)
+	}
+
+	return uid, nil
+}
+
+// mnemonicWords is the number of words of a mnemonic.
+const mnemonicWords = 4
+
+// Mnemonic returns a short human readable mnemonic of the uuid made of 4
+// words from wordlist joined by dashes. Each word is picked by one 32-bit
+// chunk of the uuid, so the same uuid and wordlist always give the same
+// mnemonic. It's meant for humans to confirm they're looking at the same
+// uuid, not as an encoding: different uuids may share a mnemonic.
+// It returns an empty string if wordlist is empty.
+func (id UUID) Mnemonic(wordlist []string) string {
+	if len(wordlist) == 0 {
+		return ""
+	}
+
+	words := make([]string, mnemonicWords)
+	for i := range words {
+		chunk := binary.BigEndian.Uint32(id[i*4:])
+		words[i] = wordlist[chunk%uint32(len(wordlist))]
+	}
+	return strings.Join(words, "-")
+}
+
+// base62Alphabet is the base62 alphabet in ASCII order, so the lexical order
+// of fixed width base62 strings matches their numeric order.
+const base62Alphabet = "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz"
+
+// base62Length is the number of base62 digits needed for 128 bits.
+const base62Length = 22
+
+// Base62 returns the uuid as a 128-bit big-endian integer encoded in base62
+// using the [0-9A-Za-z] alphabet, left-padded with '0' to 22 characters.
+// Since the width is fixed, the lexical order of the encoded strings is the
+// same as the byte order of the UUIDs.
+func (id UUID) Base62() string {
+	var buf [base62Length]byte
+	hi, lo := toUint128(id)
+	for i := len(buf) - 1; i >= 0; i-- {
+		var r uint64
+		hi, r = bits.Div64(0, hi, 62)
+		lo, r = bits.Div64(r, lo, 62)
+		buf[i] = base62Alphabet[r]
+	}
+	return string(buf[:])
+}
+
+// ParseBase62 parses a UUID from the 22 characters base62 form returned by
+// UUID.Base62. Shorter inputs are accepted as if left-padded with '0', so
+// the leading zeros stripped by other encoders are not required, e.g. "1"
+// gives 00000000-0000-0000-0000-000000000001.
+func ParseBase62(s string) (UUID, error) {
+	if len(s) == 0 || len(s) > base62Length {
+		return Nil, fmt.Errorf("uuid: incorrect base62 length: %s", s)
+	}
+
+	var hi, lo uint64
+	for i := 0; i < len(s); i++ {
+		v := strings.IndexByte(base62Alphabet, s[i])
+		if v < 0 {
+			return Nil, fmt.Errorf("uuid: invalid base62 string: %s", s)
+		}
+
+		// (hi, lo) = (hi, lo) * 62 + v, rejecting values above 128 bits.
+		overflow, h := bits.Mul64(hi, 62)
+		c, l := bits.Mul64(lo, 62)
+		l, carry := bits.Add64(l, uint64(v), 0)
+		h, carry1 := bits.Add64(h, c, carry)
+		if overflow != 0 || carry1 != 0 {
+			return Nil, fmt.Errorf("uuid: base62 value overflows 128 bits: %s", s)
+		}
+
+		hi, lo = h, l
+	}
+
+	return fromUint128(hi, lo), nil
+}
+
+// RedisKeyTag returns a Redis Cluster key prefix with a hash tag made of the
+// first 4 bytes (8 hex digits) of the uuid, formatted as prefix:{xxxxxxxx}.
+// Redis only hashes the content of the braces, so the keys built by
+// appending to the returned tag land on the same slot whenever their UUIDs
+// share the first 4 bytes.
+func (id UUID) RedisKeyTag(prefix string) string {
+	var tag [8]byte
+	hex.Encode(tag[:], id[:4])
+	return prefix + ":{" + string(tag[:]) + "}"
+}
+
+// ANSI escape sequences used by ColorString.
+const (
+	ansiVersion = "\x1b[33m" // yellow
+	ansiVariant = "\x1b[36m" // cyan
+	ansiReset   = "\x1b[0m"
+)
+
+// ColorString returns the canonical string of the uuid with the version
+// nibble and the variant character wrapped in ANSI color escape sequences,
+// for terminal tools. When color is false, e.g. the output isn't a TTY, the
+// plain canonical string is returned.
+func (id UUID) ColorString(color bool) string {
+	s := id.String()
+	if !color {
+		return s
+	}
+
+	// the version is the 15th character, the variant bits are the top bits
+	// of the 20th character.
+	return s[:14] + ansiVersion + s[14:15] + ansiReset +
+		s[15:19] + ansiVariant + s[19:20] + ansiReset + s[20:]
+}
+
+// Hex returns the 32 lowercase hex digits of the uuid without dashes, the
+// form parsed by ParseHyphenless.
+func (id UUID) Hex() string {
+	var buf [32]byte
+	hex.Encode(buf[:], id[:])
+	return string(buf[:])
+}
+
+// URN returns the uuid in the URN form defined in RFC 4122, that is the
+// canonical string prefixed by urn:uuid:, the form parsed by ParseURN.
+func (id UUID) URN() string {
+	var buf [len(urnPrefix) + 36]byte
+	copy(buf[:], urnPrefix)
+	encodeHex(buf[len(urnPrefix):], id)
+	return string(buf[:])
+}
+
+// Filename returns the uuid as a file name made of the 32 lowercase hex
+// digits without dashes, followed by the given extension, e.g. "json" or
+// ".json" both give "000102030405460788090a0b0c0d0e0f.json". The extension
+// is sanitized: only the letters, the digits, '_' and '-' are kept, and the
+// dots only as single separators, e.g. "tar.gz", so "../../etc/passwd"
+// gives "000102030405460788090a0b0c0d0e0f.etcpasswd". The name therefore
+// only contains characters that are safe on every common filesystem, and
+// since the hex is lowercase, distinct UUIDs never collide even on
+// case-insensitive filesystems. An empty extension gives the bare hex name.
+func (id UUID) Filename(ext string) string {
+	name := id.Hex()
+	for _, part := range strings.Split(ext, ".") {
+		if part = strings.Map(filenameRune, part); part != "" {
+			name += "." + part
+		}
+	}
+	return name
+}
+
+// filenameRune returns r if it's allowed in the extension of Filename, -1
+// otherwise to drop it.
+func filenameRune(r rune) rune {
+	if (r >= '0' && r <= '9') || (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') || r == '_' || r == '-' {
+		return r
+	}
+	return -1
+}
+
+// Format implements fmt.Formatter: %s and %v give the canonical string, %q
+// the quoted canonical string, and %x and %X the 32 hex digits without
+// dashes in lower and upper case. The width pads the result with spaces, on
+// the left unless the - flag is set, the other flags are ignored.
+func (id UUID) Format(f fmt.State, verb rune) {
+	var s string
+	switch verb {
+	case 's', 'v':
+		s = id.String()
+	case 'q':
+		s = `"` + id.String() + `"`
+	case 'x':
+		s = id.Hex()
+	case 'X':
+		s = strings.ToUpper(id.Hex())
+	default:
+		s = "%!" + string(verb) + "(uuid.UUID=" + id.String() + ")"
+	}
+
+	if w, ok := f.Width(); ok && w > len(s) {
+		pad := strings.Repeat(" ", w-len(s))
+		if f.Flag('-') {
+			s += pad
+		} else {
+			s = pad + s
+		}
+	}
+	_, _ = io.WriteString(f, s)
+}
@@ -0,0 +1,587 @@
+package uuid
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"testing"
+)
+
+func TestDetectEncoding(t *testing.T) {
+	table := []struct {
+		name string
+		in   string
+		want Encoding
+	}{
+		{"canonical", StaticUUID, EncodingCanonical},
+		{"hyphenless", strings.ReplaceAll(StaticUUID, "-", ""), EncodingHyphenless},
+		{"braced", "{" + StaticUUID + "}", EncodingBraced},
+		{"urn", "urn:uuid:" + StaticUUID, EncodingURN},
+		{"urn uppercase", "URN:UUID:" + StaticUUID, EncodingURN},
+		{"base32", "000G40R40N30F209185GR38E1W", EncodingBase32},
+		{"base32 lowercase", "000g40r40n30f209185gr38e1w", EncodingBase32},
+		{"base64", "AAECAwQFRgeICQoLDA0ODw", EncodingBase64},
+		{"base64 url safe", "AAECAwQFRgeICQoLDA0O_-", EncodingBase64},
+	}
+
+	for _, tt := range table {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := DetectEncoding(tt.in)
+			if !ok {
+				t.Fatal("expected encoding to be detected")
+			}
+
+			if got != tt.want {
+				t.Fatalf("unexpected encoding: %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDetectEncoding_Unknown(t *testing.T) {
+	table := []struct {
+		name string
+		in   string
+	}{
+		{"empty", ""},
+		{"canonical invalid char", "00010203-0405-4607-8809-0a0b0c0d0e0g"},
+		{"canonical misplaced dash", "000102030-405-4607-8809-0a0b0c0d0e0f"},
+		{"hyphenless invalid char", strings.Repeat("g", 32)},
+		{"braced unbalanced", "{" + StaticUUID + ")"},
+		{"urn wrong prefix", "urn:uid::" + StaticUUID},
+		{"base32 excluded letter", strings.Repeat("U", 26)},
+		{"base64 invalid char", strings.Repeat("+", 22)},
+	}
+
+	for _, tt := range table {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := DetectEncoding(tt.in)
+			if ok {
+				t.Fatal("unexpected detected encoding:", got)
+			}
+
+			if got != EncodingUnknown {
+				t.Fatal("unexpected encoding:", got)
+			}
+		})
+	}
+}
+
+func TestEncoding_String(t *testing.T) {
+	if EncodingCanonical.String() != "canonical" {
+		t.Fatal("unexpected name:", EncodingCanonical.String())
+	}
+
+	if Encoding(100).String() != "unknown" {
+		t.Fatal("unexpected name:", Encoding(100).String())
+	}
+}
+
+func TestUUID_Mnemonic(t *testing.T) {
+	wordlist := []string{"alpha", "bravo", "charlie", "delta", "echo", "foxtrot", "golf"}
+
+	uid := must(t, func() (UUID, error) { return Parse(StaticUUID) })
+	// chunks are 0x00010203, 0x04054607, 0x88090a0b and 0x0c0d0e0f.
+	if got := uid.Mnemonic(wordlist); got != "golf-alpha-charlie-echo" {
+		t.Fatal("unexpected mnemonic:", got)
+	}
+
+	uid = must(t, New)
+	got := uid.Mnemonic(wordlist)
+	if got != uid.Mnemonic(wordlist) {
+		t.Fatal("mnemonic is not deterministic:", got)
+	}
+
+	if n := len(strings.Split(got, "-")); n != 4 {
+		t.Fatal("unexpected word count:", n)
+	}
+
+	if got := uid.Mnemonic(nil); got != "" {
+		t.Fatal("unexpected mnemonic:", got)
+	}
+}
+
+func TestUUID_Base32(t *testing.T) {
+	table := []struct {
+		name string
+		in   string
+		want string
+	}{
+		{"nil", "00000000-0000-0000-0000-000000000000", "00000000000000000000000000"},
+		{"static", StaticUUID, "000G40R40N30F209185GR38E1W"},
+		{"max", "ffffffff-ffff-ffff-ffff-ffffffffffff", "ZZZZZZZZZZZZZZZZZZZZZZZZZW"},
+	}
+
+	for _, tt := range table {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			uid := must(t, func() (UUID, error) { return Parse(tt.in) })
+			got := uid.Base32()
+			if got != tt.want {
+				t.Fatal("unexpected base32:", got)
+			}
+
+			back, err := ParseBase32(got)
+			if err != nil {
+				t.Fatal("unexpected error:", err)
+			}
+
+			if back != uid {
+				t.Fatal("unexpected uuid:", back)
+			}
+		})
+	}
+}
+
+func TestParseBase32(t *testing.T) {
+	table := []struct {
+		name string
+		in   string
+	}{
+		{"upper", "000G40R40N30F209185GR38E1W"},
+		{"lower", "000g40r40n30f209185gr38e1w"},
+		{"hyphens", "000G40-R40N30-F20918-5GR38E1W"},
+		{"max hyphens", "000G4-0R40N-30F20-9185G-R38E-1W"},
+		{"one hyphen", "000G40R40N30F-209185GR38E1W"},
+	}
+
+	for _, tt := range table {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			uid, err := ParseBase32(tt.in)
+			if err != nil {
+				t.Fatal("unexpected error:", err)
+			}
+
+			if uid.String() != StaticUUID {
+				t.Fatal("unexpected uuid:", uid)
+			}
+		})
+	}
+}
+
+func TestUUID_Base32_RoundTrip(t *testing.T) {
+	// takes 100 random samples.
+	for i := 0; i < 100; i++ {
+		uid := must(t, New)
+		s := uid.Base32()
+		if len(s) != 26 {
+			t.Fatal("unexpected base32 length:", s)
+		}
+
+		back := must(t, func() (UUID, error) { return ParseBase32(strings.ToLower(s)) })
+		if back != uid {
+			t.Fatal("unexpected uuid:", back)
+		}
+	}
+}
+
+func TestParseBase32_Errors(t *testing.T) {
+	table := []struct {
+		name string
+		in   string
+	}{
+		{"empty", ""},
+		{"short", strings.Repeat("0", 25)},
+		{"long", strings.Repeat("0", 27)},
+		{"excluded letter", strings.Repeat("U", 26)},
+		{"only hyphens", strings.Repeat("-", 26)},
+		{"trailing hyphens", strings.Repeat("0", 26) + "-----"},
+		{"trailing hyphen", "000G40R40N30F209185GR38E1W-"},
+		{"leading hyphen", "-000G40R40N30F209185GR38E1W"},
+		{"double hyphen", "000G40--R40N30F209185GR38E1W"},
+		{"interleaved hyphens", "-0-0-0-0-0-0" + strings.Repeat("0", 20)},
+		{"too many hyphens", "000G-40R4-0N30-F209-185G-R38E-1W"},
+		{"hyphens at textual length", strings.Repeat("0", 26) + "------"},
+		{"padding bits X", "000G40R40N30F209185GR38E1X"},
+		{"padding bits Y", "000G40R40N30F209185GR38E1Y"},
+		{"padding bits Z", "000G40R40N30F209185GR38E1Z"},
+		{"padding bits lowercase", "000G40R40N30F209185GR38E1z"},
+	}
+
+	for _, tt := range table {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			uid, err := ParseBase32(tt.in)
+			if err == nil {
+				t.Fatal("expected error, got nil")
+			}
+
+			if uid != Nil {
+				t.Fatal("unexpected nil uuid:", uid)
+			}
+		})
+	}
+}
+
+func TestUUID_Base62(t *testing.T) {
+	table := []struct {
+		name string
+		in   string
+		want string
+	}{
+		{"nil", "00000000-0000-0000-0000-000000000000", "0000000000000000000000"},
+		{"one", "00000000-0000-0000-0000-000000000001", "0000000000000000000001"},
+		{"sixty two", "00000000-0000-0000-0000-00000000003e", "0000000000000000000010"},
+		{"max", "ffffffff-ffff-ffff-ffff-ffffffffffff", "7n42DGM5Tflk9n8mt7Fhc7"},
+	}
+
+	for _, tt := range table {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			uid := must(t, func() (UUID, error) { return Parse(tt.in) })
+			got := uid.Base62()
+			if got != tt.want {
+				t.Fatal("unexpected base62:", got)
+			}
+
+			back, err := ParseBase62(got)
+			if err != nil {
+				t.Fatal("unexpected error:", err)
+			}
+
+			if back != uid {
+				t.Fatal("unexpected uuid:", back)
+			}
+		})
+	}
+}
+
+func TestUUID_Base62_RoundTrip(t *testing.T) {
+	// takes 100 random samples.
+	for i := 0; i < 100; i++ {
+		uid := must(t, New)
+		s := uid.Base62()
+		if len(s) != 22 {
+			t.Fatal("unexpected base62 length:", s)
+		}
+
+		back := must(t, func() (UUID, error) { return ParseBase62(s) })
+		if back != uid {
+			t.Fatal("unexpected uuid:", back)
+		}
+	}
+}
+
+func TestUUID_Base62_Order(t *testing.T) {
+	uids := make([]UUID, 100)
+	strs := make([]string, len(uids))
+	for i := range uids {
+		uids[i] = must(t, New)
+	}
+
+	sort.Slice(uids, func(i, j int) bool { return greater128(uids[j], uids[i]) })
+	for i, uid := range uids {
+		strs[i] = uid.Base62()
+	}
+
+	if !sort.StringsAreSorted(strs) {
+		t.Fatal("base62 encoding doesn't preserve the order")
+	}
+}
+
+func TestParseBase62_Short(t *testing.T) {
+	table := []struct {
+		name string
+		in   string
+		want string
+	}{
+		{"nil", "0", "00000000-0000-0000-0000-000000000000"},
+		{"one", "1", "00000000-0000-0000-0000-000000000001"},
+		{"sixty two", "10", "00000000-0000-0000-0000-00000000003e"},
+		{"leading zeros", "00010", "00000000-0000-0000-0000-00000000003e"},
+		{"max", "7n42DGM5Tflk9n8mt7Fhc7", "ffffffff-ffff-ffff-ffff-ffffffffffff"},
+	}
+
+	for _, tt := range table {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			uid, err := ParseBase62(tt.in)
+			if err != nil {
+				t.Fatal("unexpected error:", err)
+			}
+
+			if uid.String() != tt.want {
+				t.Fatal("unexpected uuid:", uid)
+			}
+		})
+	}
+}
+
+func TestParseBase62_Errors(t *testing.T) {
+	table := []struct {
+		name string
+		in   string
+	}{
+		{"empty", ""},
+		{"long", strings.Repeat("0", 23)},
+		{"invalid chars", "000000000000000000000-"},
+		{"short invalid chars", "1_"},
+		{"overflow", "7n42DGM5Tflk9n8mt7Fhc8"},
+		{"overflow max", strings.Repeat("z", 22)},
+	}
+
+	for _, tt := range table {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			uid, err := ParseBase62(tt.in)
+			if err == nil {
+				t.Fatal("expected error, got nil")
+			}
+
+			if uid != Nil {
+				t.Fatal("unexpected nil uuid:", uid)
+			}
+		})
+	}
+}
+
+func TestUUID_Base64(t *testing.T) {
+	uid := MustParse(StaticUUID)
+	if got := uid.Base64(); got != "AAECAwQFRgeICQoLDA0ODw" {
+		t.Fatal("unexpected base64:", got)
+	}
+
+	if got := Nil.Base64(); got != "AAAAAAAAAAAAAAAAAAAAAA" {
+		t.Fatal("unexpected base64:", got)
+	}
+
+	// takes 100 random samples.
+	for i := 0; i < 100; i++ {
+		uid := must(t, New)
+		back := must(t, func() (UUID, error) { return ParseBase64(uid.Base64()) })
+		if back != uid {
+			t.Fatal("unexpected uuid:", back)
+		}
+	}
+}
+
+func TestParseBase64_Errors(t *testing.T) {
+	table := []struct {
+		name string
+		in   string
+	}{
+		{"empty", ""},
+		{"short", "AAECAwQFRgeICQoLDA0O"},
+		{"long", "AAECAwQFRgeICQoLDA0ODxA"},
+		{"padded", "AAECAwQFRgeICQoLDA0ODw=="},
+		{"standard alphabet", "AAECAwQFRgeICQoLDA0O+/"},
+		{"non zero padding bits", "AAECAwQFRgeICQoLDA0ODx"},
+	}
+
+	for _, tt := range table {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			uid, err := ParseBase64(tt.in)
+			if err == nil {
+				t.Fatal("expected error, got nil")
+			}
+
+			if uid != Nil {
+				t.Fatal("unexpected nil uuid:", uid)
+			}
+		})
+	}
+}
+
+func TestNewToken(t *testing.T) {
+	seen := make(map[string]bool)
+
+	// takes 100 random samples.
+	for i := 0; i < 100; i++ {
+		token, err := NewToken()
+		if err != nil {
+			t.Fatal("unexpected error:", err)
+		}
+
+		if len(token) != 22 || seen[token] {
+			t.Fatal("unexpected token:", token)
+		}
+		seen[token] = true
+
+		uid := must(t, func() (UUID, error) { return ParseBase64(token) })
+		if uid == Nil || !IsV4(uid) {
+			t.Fatal("unexpected uuid:", uid)
+		}
+	}
+}
+
+func TestUUID_RedisKeyTag(t *testing.T) {
+	uid := must(t, func() (UUID, error) { return Parse(StaticUUID) })
+	if got := uid.RedisKeyTag("user"); got != "user:{00010203}" {
+		t.Fatal("unexpected key tag:", got)
+	}
+
+	// the same tag bytes give the same tag.
+	other := must(t, New)
+	copy(other[:4], uid[:4])
+	if got := other.RedisKeyTag("user"); got != uid.RedisKeyTag("user") {
+		t.Fatal("unexpected key tag:", got)
+	}
+
+	other[3] ^= 0x01
+	if got := other.RedisKeyTag("user"); got == uid.RedisKeyTag("user") {
+		t.Fatal("unexpected key tag:", got)
+	}
+}
+
+func TestUUID_ColorString(t *testing.T) {
+	uid := must(t, func() (UUID, error) { return Parse(StaticUUID) })
+
+	want := "00010203-0405-\x1b[33m4\x1b[0m607-\x1b[36m8\x1b[0m809-0a0b0c0d0e0f"
+	if got := uid.ColorString(true); got != want {
+		t.Fatalf("unexpected colored string: %q", got)
+	}
+
+	plain := uid.ColorString(false)
+	if plain != StaticUUID || strings.Contains(plain, "\x1b[") {
+		t.Fatalf("unexpected plain string: %q", plain)
+	}
+}
+
+func TestUUID_Hex(t *testing.T) {
+	uid := MustParse(StaticUUID)
+	if got := uid.Hex(); got != "000102030405460788090a0b0c0d0e0f" {
+		t.Fatal("unexpected hex:", got)
+	}
+
+	// takes 100 random samples.
+	for i := 0; i < 100; i++ {
+		uid := must(t, New)
+		if got, want := uid.Hex(), strings.ReplaceAll(uid.String(), "-", ""); got != want {
+			t.Fatalf("unexpected hex: %s, want %s", got, want)
+		}
+
+		back := must(t, func() (UUID, error) { return ParseHyphenless(uid.Hex()) })
+		if back != uid {
+			t.Fatal("unexpected uuid:", back)
+		}
+	}
+}
+
+func BenchmarkUUID_Hex(b *testing.B) {
+	uid := MustParse(StaticUUID)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_ = uid.Hex()
+	}
+}
+
+func BenchmarkUUID_Hex_ReplaceAll(b *testing.B) {
+	uid := MustParse(StaticUUID)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_ = strings.ReplaceAll(uid.String(), "-", "")
+	}
+}
+
+func TestUUID_URN(t *testing.T) {
+	uid := MustParse(StaticUUID)
+	if got := uid.URN(); got != "urn:uuid:"+StaticUUID {
+		t.Fatal("unexpected urn:", got)
+	}
+
+	if got := Nil.URN(); got != "urn:uuid:00000000-0000-0000-0000-000000000000" {
+		t.Fatal("unexpected urn:", got)
+	}
+
+	// takes 100 random samples.
+	for i := 0; i < 100; i++ {
+		uid := must(t, New)
+		back := must(t, func() (UUID, error) { return ParseURN(uid.URN()) })
+		if back != uid {
+			t.Fatal("unexpected uuid:", back)
+		}
+	}
+}
+
+func TestUUID_Filename(t *testing.T) {
+	uid := must(t, func() (UUID, error) { return Parse(StaticUUID) })
+	table := []struct {
+		ext  string
+		want string
+	}{
+		{"", "000102030405460788090a0b0c0d0e0f"},
+		{"json", "000102030405460788090a0b0c0d0e0f.json"},
+		{".json", "000102030405460788090a0b0c0d0e0f.json"},
+		{"tar.gz", "000102030405460788090a0b0c0d0e0f.tar.gz"},
+		{"my_ext-1", "000102030405460788090a0b0c0d0e0f.my_ext-1"},
+		{"../../etc/passwd", "000102030405460788090a0b0c0d0e0f.etcpasswd"},
+		{"..", "000102030405460788090a0b0c0d0e0f"},
+		{"a/b", "000102030405460788090a0b0c0d0e0f.ab"},
+		{`a\b`, "000102030405460788090a0b0c0d0e0f.ab"},
+		{"a/b:c", "000102030405460788090a0b0c0d0e0f.abc"},
+		{"json\x00.exe", "000102030405460788090a0b0c0d0e0f.json.exe"},
+		{"*.txt", "000102030405460788090a0b0c0d0e0f.txt"},
+		{"tar..gz.", "000102030405460788090a0b0c0d0e0f.tar.gz"},
+		{"jsón", "000102030405460788090a0b0c0d0e0f.jsn"},
+	}
+
+	for _, tt := range table {
+		tt := tt
+		t.Run(tt.ext, func(t *testing.T) {
+			if got := uid.Filename(tt.ext); got != tt.want {
+				t.Fatal("unexpected filename:", got)
+			}
+		})
+	}
+}
+
+func TestUUID_Filename_Unique(t *testing.T) {
+	seen := make(map[string]bool)
+
+	// takes 1000 random samples.
+	for i := 0; i < 1000; i++ {
+		name := strings.ToLower(must(t, New).Filename("bin"))
+		if seen[name] {
+			t.Fatal("unexpected duplicated filename:", name)
+		}
+		seen[name] = true
+
+		if !isAll(strings.TrimSuffix(name, ".bin"), isHex) {
+			t.Fatal("unexpected filename:", name)
+		}
+	}
+}
+
+func TestUUID_Format(t *testing.T) {
+	uid := MustParse(StaticUUID)
+	table := []struct {
+		format string
+		want   string
+	}{
+		{"%s", StaticUUID},
+		{"%v", StaticUUID},
+		{"%+v", StaticUUID},
+		{"%q", `"` + StaticUUID + `"`},
+		{"%x", "000102030405460788090a0b0c0d0e0f"},
+		{"%X", "000102030405460788090A0B0C0D0E0F"},
+		{"%38s", "  " + StaticUUID},
+		{"%-38s|", StaticUUID + "  |"},
+		{"%d", "%!d(uuid.UUID=" + StaticUUID + ")"},
+	}
+
+	for _, tt := range table {
+		tt := tt
+		t.Run(tt.format, func(t *testing.T) {
+			if got := fmt.Sprintf(tt.format, uid); got != tt.want {
+				t.Fatalf("unexpected result: %q", got)
+			}
+		})
+	}
+
+	// nested values are formatted as well.
+	record := struct {
+		ID   UUID
+		Tags []UUID
+	}{uid, []UUID{Nil}}
+	if got := fmt.Sprintf("%v", record); got != "{"+StaticUUID+" ["+Nil.String()+"]}" {
+		t.Fatalf("unexpected result: %q", got)
+	}
+
+	if got := fmt.Sprintf("%x", &uid); got != "000102030405460788090a0b0c0d0e0f" {
+		t.Fatalf("unexpected result: %q", got)
+	}
+}
@@ -0,0 +1,20 @@
+package uuid
+
+// Set implements flag.Value, it parses the canonical string of a UUID with
+// Parse and returns its error unchanged, so a UUID can be a command-line
+// flag with flag.Var(&id, "id", "usage"). The uuid is left unchanged on
+// error.
+func (id *UUID) Set(s string) error {
+	uid, err := Parse(s)
+	if err != nil {
+		return err
+	}
+
+	*id = uid
+	return nil
+}
+
+// Get implements flag.Getter, it returns the uuid as a UUID.
+func (id *UUID) Get() interface{} {
+	return *id
+}
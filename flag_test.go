@@ -0,0 +1,60 @@
+package uuid
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"testing"
+)
+
+func TestUUID_Set(t *testing.T) {
+	var uid UUID
+	if err := uid.Set(StaticUUID); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	if uid.String() != StaticUUID {
+		t.Fatal("unexpected uuid:", uid)
+	}
+
+	if got, ok := uid.Get().(UUID); !ok || got != uid {
+		t.Fatal("unexpected value:", uid.Get())
+	}
+
+	if err := uid.Set("not a uuid"); err != ErrLength {
+		t.Fatal("unexpected error:", err)
+	}
+
+	if uid.String() != StaticUUID {
+		t.Fatal("unexpected modified uuid:", uid)
+	}
+}
+
+func TestUUID_Set_FlagSet(t *testing.T) {
+	var uid UUID
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	fs.Var(&uid, "id", "the uuid")
+
+	if err := fs.Parse([]string{"-id", "12345678-1234-1234-1234-12345678901g"}); err == nil {
+		t.Fatal("expected error, got nil")
+	}
+
+	if err := fs.Parse([]string{"-id", StaticUUID}); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	if uid.String() != StaticUUID {
+		t.Fatal("unexpected uuid:", uid)
+	}
+}
+
+func ExampleUUID_Set() {
+	var id UUID
+	fs := flag.NewFlagSet("example", flag.ExitOnError)
+	fs.Var(&id, "id", "the uuid of the order")
+	_ = fs.Parse([]string{"-id", "6ba7b810-9dad-11d1-80b4-00c04fd430c8"})
+
+	fmt.Println(id)
+	// Output: 6ba7b810-9dad-11d1-80b4-00c04fd430c8
+}
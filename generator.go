@@ -0,0 +1,244 @@
+package uuid
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+)
+
+// ConcurrentSafe is implemented by the generators that declare whether they
+// are safe for concurrent use, so that a pool can decide if a generator must
+// be guarded by a mutex.
+type ConcurrentSafe interface {
+	// Concurrent reports whether NewUUID can be called concurrently.
+	Concurrent() bool
+}
+
+// IsConcurrentSafe reports whether the given generator declares itself safe
+// for concurrent use. A generator that doesn't implement ConcurrentSafe is
+// assumed to be unsafe.
+func IsConcurrentSafe(g Generator) bool {
+	cs, ok := g.(ConcurrentSafe)
+	return ok && cs.Concurrent()
+}
+
+// maxDisplayFriendlyAttempts is the maximum number of UUIDs generated by
+// NewDisplayFriendly before giving up.
+const maxDisplayFriendlyAttempts = 8
+
+// NewDisplayFriendly generates a new UUID using the given generator and
+// regenerates it when the first 8 hex characters are all identical
+// (e.g. 00000000-...), since such UUIDs look broken to the users.
+//
+// For a random UUID only 16 of 2^32 possible leading patterns are rejected,
+// so the entropy loss is negligible. An error is returned when the generator
+// fails or keeps producing such a prefix after a bounded number of attempts.
+func NewDisplayFriendly(g Generator) (UUID, error) {
+	for i := 0; i < maxDisplayFriendlyAttempts; i++ {
+		uid, err := g.NewUUID()
+		if err != nil {
+			return Nil, err
+		}
+
+		if !hasRepeatedPrefix(uid) {
+			return uid, nil
+		}
+	}
+
+	return Nil, fmt.Errorf("uuid: no display friendly UUID after %d attempts", maxDisplayFriendlyAttempts)
+}
+
+// hasRepeatedPrefix reports whether the first 8 hex characters of the uuid
+// are all the same character.
+func hasRepeatedPrefix(uid UUID) bool {
+	c := uid[0] & 0x0f
+	if uid[0]>>4 != c {
+		return false
+	}
+
+	for _, b := range uid[1:4] {
+		if b>>4 != c || b&0x0f != c {
+			return false
+		}
+	}
+	return true
+}
+
+// maxSortableAttempts is the maximum number of UUIDs generated by
+// NewSortableString before giving up.
+const maxSortableAttempts = 64
+
+// NewSortableString generates a new UUID using the given generator and
+// returns its canonical string, making sure it sorts strictly after the
+// given existing string key. The UUID is regenerated when it doesn't, up to
+// a bounded number of attempts. With a time-ordered generator such as v7 the
+// first attempt almost always succeeds once after is an older UUID key.
+// An error is returned if no canonical string can sort after the key.
+func NewSortableString(g Generator, after string) (string, error) {
+	// Max gives the largest canonical string.
+	if after >= Max.String() {
+		return "", fmt.Errorf("uuid: no UUID sorts after %q", after)
+	}
+
+	for i := 0; i < maxSortableAttempts; i++ {
+		uid, err := g.NewUUID()
+		if err != nil {
+			return "", err
+		}
+
+		if s := uid.String(); s > after {
+			return s, nil
+		}
+	}
+
+	return "", fmt.Errorf("uuid: no UUID sorting after %q after %d attempts", after, maxSortableAttempts)
+}
+
+// PersistentUniqueGenerator decorates a generator to guarantee that the
+// generated UUIDs are not already known by a persistent store, for systems
+// that can't tolerate a collision at all.
+type PersistentUniqueGenerator struct {
+	inner       Generator
+	exists      func(UUID) (bool, error)
+	maxAttempts int
+}
+
+// NewPersistentUniqueGenerator creates a new instance of
+// PersistentUniqueGenerator generating UUIDs with inner and checking them
+// against the store with exists, up to maxAttempts times per UUID.
+func NewPersistentUniqueGenerator(inner Generator, exists func(UUID) (bool, error), maxAttempts int) *PersistentUniqueGenerator {
+	return &PersistentUniqueGenerator{
+		inner:       inner,
+		exists:      exists,
+		maxAttempts: maxAttempts,
+	}
+}
+
+// Concurrent reports whether the inner generator is safe for concurrent use,
+// the exists function must be safe for concurrent use as well.
+func (p *PersistentUniqueGenerator) Concurrent() bool {
+	return IsConcurrentSafe(p.inner)
+}
+
+// NewUUID generates a new UUID with the inner generator and regenerates it
+// while exists reports it as already stored. An error is returned if the
+// inner generator or exists fails, or if no unique UUID is found within the
+// maximum number of attempts.
+func (p *PersistentUniqueGenerator) NewUUID() (UUID, error) {
+	for i := 0; i < p.maxAttempts; i++ {
+		uid, err := p.inner.NewUUID()
+		if err != nil {
+			return Nil, err
+		}
+
+		found, err := p.exists(uid)
+		if err != nil {
+			return Nil, err
+		}
+
+		if !found {
+			return uid, nil
+		}
+	}
+
+	return Nil, fmt.Errorf("uuid: no unique UUID after %d attempts", p.maxAttempts)
+}
+
+// NewVanity generates UUIDs using the given generator until the canonical
+// string starts with the given prefix, e.g. "cafe", ignoring the letter case.
+// The prefix must be the beginning of a canonical string: hex digits, with
+// dashes at their canonical positions. Each hex digit of the prefix makes a
+// match 16 times less likely, so long prefixes are exponentially slow.
+// An error is returned if the prefix is invalid, if the generator fails, or
+// if no match is found within maxAttempts.
+func NewVanity(g Generator, prefix string, maxAttempts int) (UUID, error) {
+	if len(prefix) > 36 || !isCanonical(prefix) {
+		return Nil, fmt.Errorf("uuid: invalid vanity prefix: %s", prefix)
+	}
+
+	prefix = strings.ToLower(prefix)
+	for i := 0; i < maxAttempts; i++ {
+		uid, err := g.NewUUID()
+		if err != nil {
+			return Nil, err
+		}
+
+		if strings.HasPrefix(uid.String(), prefix) {
+			return uid, nil
+		}
+	}
+
+	return Nil, fmt.Errorf("uuid: no UUID starting with %s after %d attempts", prefix, maxAttempts)
+}
+
+// BufferedV4Generator generates version 4 UUIDs like V4Generator, but reads
+// the random data of many UUIDs at once from rand.Reader, amortizing the
+// cost of the reads under load. The bytes of each UUID are zeroed in the
+// buffer once used, and the whole buffer is refilled when exhausted, so no
+// random data is ever handed out twice. It's safe for concurrent use.
+type BufferedV4Generator struct {
+	factory ReaderFactory
+
+	mu  sync.Mutex
+	buf []byte
+	off int
+}
+
+// NewBufferedV4Generator creates a new instance of BufferedV4Generator
+// buffering the random data of size UUIDs, 16 bytes each. A size below 1 is
+// treated as 1.
+func NewBufferedV4Generator(size int) *BufferedV4Generator {
+	return newBufferedV4Generator(SecureReader, size)
+}
+
+// newBufferedV4Generator creates a BufferedV4Generator reading from the
+// given factory.
+func newBufferedV4Generator(factory ReaderFactory, size int) *BufferedV4Generator {
+	if size < 1 {
+		size = 1
+	}
+
+	buf := make([]byte, 16*size)
+	return &BufferedV4Generator{
+		factory: factory,
+		buf:     buf,
+		off:     len(buf),
+	}
+}
+
+// Concurrent returns true, BufferedV4Generator guards its buffer with a
+// mutex.
+func (b *BufferedV4Generator) Concurrent() bool {
+	return true
+}
+
+// NewUUID generates a new UUID from the next 16 bytes of the buffer, refilling
+// it first when exhausted, and sets the version and variant bits to satisfy
+// the UUID v4 standard.
+func (b *BufferedV4Generator) NewUUID() (UUID, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.off == len(b.buf) {
+		if _, err := io.ReadFull(b.factory(), b.buf); err != nil {
+			// discard the partial read.
+			for i := range b.buf {
+				b.buf[i] = 0
+			}
+			return Nil, err
+		}
+		b.off = 0
+	}
+
+	var uid UUID
+	used := b.buf[b.off : b.off+16]
+	copy(uid[:], used)
+	for i := range used {
+		used[i] = 0
+	}
+	b.off += 16
+
+	SetV4Bits(&uid)
+	return uid, nil
+}
@@ -0,0 +1,318 @@
+package uuid
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+)
+
+// countingGenerator counts the number of calls to the wrapped generator.
+type countingGenerator struct {
+	Generator
+	calls int
+}
+
+func (c *countingGenerator) NewUUID() (UUID, error) {
+	c.calls++
+	return c.Generator.NewUUID()
+}
+
+func TestIsConcurrentSafe(t *testing.T) {
+	v1, err := NewV1Generator(nil, SecureReader)
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	v6, err := NewV6Generator(nil, SecureReader)
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	table := []struct {
+		name string
+		g    Generator
+		want bool
+	}{
+		{"v1", v1, true},
+		{"v4", NewV4Generator(SecureReader), true},
+		{"v4 buffered", NewBufferedV4Generator(4), true},
+		{"v5", NewV5Generator(NamespaceDNS, nil), true},
+		{"v6", v6, true},
+		{"v7", NewV7Generator(SecureReader), true},
+		{"v8 nanos", NewV8Nanos(SecureReader), true},
+		{"v8 series", NewV8SeriesGenerator([]byte("orders"), SecureReader), true},
+		{"persistent unique", NewPersistentUniqueGenerator(NewV4Generator(SecureReader), nil, 1), true},
+		{"persistent unique unsafe inner", NewPersistentUniqueGenerator(&countingGenerator{}, nil, 1), false},
+		{"not declared", &countingGenerator{}, false},
+	}
+
+	for _, tt := range table {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IsConcurrentSafe(tt.g); got != tt.want {
+				t.Fatalf("unexpected result: %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewDisplayFriendly(t *testing.T) {
+	// the first uuid starts with 00000000, the second one is the StaticUUID.
+	reader := bytes.NewReader(append(make([]byte, 16), []byte{
+		0x00, 0x01, 0x02, 0x03, 0x04, 0x05, 0x06, 0x07,
+		0x08, 0x09, 0x0a, 0x0b, 0x0c, 0x0d, 0x0e, 0x0f,
+	}...))
+
+	g := &countingGenerator{Generator: NewV4Generator(func() io.Reader { return reader })}
+	uid := must(t, func() (UUID, error) { return NewDisplayFriendly(g) })
+	if uid.String() != StaticUUID {
+		t.Fatal("unexpected uuid:", uid)
+	}
+
+	if g.calls != 2 {
+		t.Fatal("unexpected number of attempts:", g.calls)
+	}
+}
+
+func TestNewDisplayFriendly_Errors(t *testing.T) {
+	repeated := func() io.Reader { return bytes.NewReader(bytes.Repeat([]byte{0xaa}, 16)) }
+	_, err := NewDisplayFriendly(NewV4Generator(repeated))
+	if err == nil {
+		t.Fatal("expected error, got nil")
+	}
+
+	_, err = NewDisplayFriendly(NewV4Generator(ErrorsReader))
+	if err != io.EOF {
+		t.Fatal("unexpected error:", err)
+	}
+}
+
+func TestNewSortableString(t *testing.T) {
+	table := []string{"", "0", "LEGACY-KEY", "7fffffff", "abc-legacy", "c"}
+	for _, after := range table {
+		s, err := NewSortableString(NewV4Generator(SecureReader), after)
+		if err != nil {
+			t.Fatal("unexpected error:", err)
+		}
+
+		if s <= after {
+			t.Fatalf("%q doesn't sort after %q", s, after)
+		}
+
+		if _, err := Parse(s); err != nil {
+			t.Fatal("unexpected error:", err)
+		}
+	}
+}
+
+func TestNewSortableString_Errors(t *testing.T) {
+	table := []struct {
+		name  string
+		g     Generator
+		after string
+	}{
+		{"above max", NewV4Generator(SecureReader), "legacy-key"},
+		{"max", NewV4Generator(SecureReader), "ffffffff-ffff-ffff-ffff-ffffffffffff"},
+		{"never after", NewV4Generator(StaticReader), "1"},
+		{"reader error", NewV4Generator(ErrorsReader), ""},
+	}
+
+	for _, tt := range table {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			s, err := NewSortableString(tt.g, tt.after)
+			if err == nil {
+				t.Fatal("expected error, got nil")
+			}
+
+			if s != "" {
+				t.Fatal("unexpected string:", s)
+			}
+		})
+	}
+}
+
+func TestPersistentUniqueGenerator(t *testing.T) {
+	var first UUID
+	store := func(uid UUID) (bool, error) {
+		// the first generated uuid is already stored.
+		if first == Nil {
+			first = uid
+			return true, nil
+		}
+		return uid == first, nil
+	}
+
+	g := NewPersistentUniqueGenerator(NewV4Generator(SecureReader), store, 3)
+	uid := must(t, g.NewUUID)
+	if uid == first || uid == Nil {
+		t.Fatal("unexpected uuid:", uid)
+	}
+}
+
+func TestPersistentUniqueGenerator_Errors(t *testing.T) {
+	errStore := errors.New("store unavailable")
+	table := []struct {
+		name   string
+		inner  Generator
+		exists func(UUID) (bool, error)
+		err    error
+	}{
+		{"always exists", NewV4Generator(SecureReader), func(UUID) (bool, error) { return true, nil }, nil},
+		{"static collision", NewV4Generator(StaticReader), func(uid UUID) (bool, error) { return uid.String() == StaticUUID, nil }, nil},
+		{"store error", NewV4Generator(SecureReader), func(UUID) (bool, error) { return false, errStore }, errStore},
+		{"reader error", NewV4Generator(ErrorsReader), func(UUID) (bool, error) { return false, nil }, io.EOF},
+	}
+
+	for _, tt := range table {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			uid, err := NewPersistentUniqueGenerator(tt.inner, tt.exists, 3).NewUUID()
+			if err == nil {
+				t.Fatal("expected error, got nil")
+			}
+
+			if tt.err != nil && err != tt.err {
+				t.Fatal("unexpected error:", err)
+			}
+
+			if uid != Nil {
+				t.Fatal("unexpected nil uuid:", uid)
+			}
+		})
+	}
+}
+
+func TestNewVanity(t *testing.T) {
+	for _, prefix := range []string{"", "c", "ca", "CA"} {
+		uid, err := NewVanity(NewV4Generator(SecureReader), prefix, 100000)
+		if err != nil {
+			t.Fatal("unexpected error:", err)
+		}
+
+		if !strings.HasPrefix(uid.String(), strings.ToLower(prefix)) {
+			t.Fatal("unexpected uuid:", uid)
+		}
+	}
+
+	uid, err := NewVanity(NewV4Generator(StaticReader), "00010203-04", 1)
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	if uid.String() != StaticUUID {
+		t.Fatal("unexpected uuid:", uid)
+	}
+}
+
+func TestNewVanity_Errors(t *testing.T) {
+	table := []struct {
+		name   string
+		g      Generator
+		prefix string
+	}{
+		{"too long to find", NewV4Generator(SecureReader), "cafebabe-dead-4eef"},
+		{"impossible version", NewV4Generator(SecureReader), "00000000-0000-0"},
+		{"not hex", NewV4Generator(SecureReader), "cafg"},
+		{"misplaced dash", NewV4Generator(SecureReader), "cafe-"},
+		{"longer than uuid", NewV4Generator(SecureReader), StaticUUID + "0"},
+		{"reader error", NewV4Generator(ErrorsReader), "c"},
+	}
+
+	for _, tt := range table {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			uid, err := NewVanity(tt.g, tt.prefix, 100)
+			if err == nil {
+				t.Fatal("expected error, got nil")
+			}
+
+			if uid != Nil {
+				t.Fatal("unexpected nil uuid:", uid)
+			}
+		})
+	}
+}
+
+func TestBufferedV4Generator(t *testing.T) {
+	// the reader gives the bytes of the StaticUUID, then of the Nil UUID.
+	reads := 0
+	factory := func() io.Reader {
+		reads++
+		return bytes.NewReader(append(must(t, func() (UUID, error) { return fillUUID(StaticReader()) }).Bytes(), Nil[:]...))
+	}
+
+	g := newBufferedV4Generator(factory, 2)
+	for i := 0; i < 3; i++ {
+		if uid := must(t, g.NewUUID); uid.String() != StaticUUID {
+			t.Fatal("unexpected uuid:", uid)
+		}
+
+		if uid := must(t, g.NewUUID); uid.String() != "00000000-0000-4000-8000-000000000000" {
+			t.Fatal("unexpected uuid:", uid)
+		}
+	}
+
+	if reads != 3 {
+		t.Fatal("unexpected number of reads:", reads)
+	}
+
+	// the used bytes are zeroed.
+	if !bytes.Equal(g.buf, make([]byte, 32)) {
+		t.Fatalf("unexpected buffer: %x", g.buf)
+	}
+}
+
+func TestBufferedV4Generator_Secure(t *testing.T) {
+	g := NewBufferedV4Generator(0)
+	seen := make(map[UUID]bool)
+
+	// takes 1000 random samples.
+	for i := 0; i < 1000; i++ {
+		uid := must(t, g.NewUUID)
+		if !IsV4(uid) || seen[uid] {
+			t.Fatal("unexpected uuid:", uid)
+		}
+		seen[uid] = true
+	}
+}
+
+func TestBufferedV4Generator_ErrorsReader(t *testing.T) {
+	short := func() io.Reader { return bytes.NewReader(bytes.Repeat([]byte{0xaa}, 20)) }
+	g := newBufferedV4Generator(short, 2)
+	if _, err := g.NewUUID(); err != io.ErrUnexpectedEOF {
+		t.Fatal("unexpected error:", err)
+	}
+
+	// the partial read is discarded.
+	if !bytes.Equal(g.buf, make([]byte, 32)) {
+		t.Fatalf("unexpected buffer: %x", g.buf)
+	}
+
+	g = newBufferedV4Generator(ErrorsReader, 2)
+	if _, err := g.NewUUID(); err != io.EOF {
+		t.Fatal("unexpected error:", err)
+	}
+}
+
+func BenchmarkBufferedV4Generator(b *testing.B) {
+	g := NewBufferedV4Generator(256)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		if _, err := g.NewUUID(); err != nil {
+			b.Fatal("unexpected error:", err)
+		}
+	}
+}
+
+func BenchmarkBufferedV4Generator_V4Generator(b *testing.B) {
+	g := NewV4Generator(SecureReader)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		if _, err := g.NewUUID(); err != nil {
+			b.Fatal("unexpected error:", err)
+		}
+	}
+}
@@ -0,0 +1,116 @@
+package uuid
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"fmt"
+	"hash/fnv"
+)
+
+// localitySpill is the inverse ratio of time-based UUIDs moved to the next
+// shard by LocalityHash to avoid a single hot shard.
+const localitySpill = 8
+
+// LocalityHash returns a shard in [0, shards) for the uuid. For time-based
+// UUIDs (v1, v6 and v7) the shard is derived from the millisecond timestamp,
+// so UUIDs created within the same millisecond land on the same shard, which
+// improves temporal locality. To avoid hotspots under bursts, about one in
+// eight of them spill over to the next shard, picked by a hash of the whole
+// uuid. Other UUIDs are spread by a uniform hash of their bytes.
+// It returns 0 if shards is not positive.
+func (id UUID) LocalityHash(shards int) int {
+	if shards <= 0 {
+		return 0
+	}
+
+	h := hash64(id[:])
+	ts, ok := timestamp(id)
+	if !ok {
+		return int(h % uint64(shards))
+	}
+
+	shard := mix64(uint64(ts.UnixMilli()))
+	if h%localitySpill == 0 {
+		shard++
+	}
+	return int(shard % uint64(shards))
+}
+
+// Bucket returns a stable bucket in [0, buckets) for the uuid, picked by a
+// uniform hash of its bytes, e.g. to assign entities to experiment groups.
+// It returns 0 if buckets is not positive.
+func (id UUID) Bucket(buckets int) int {
+	if buckets <= 0 {
+		return 0
+	}
+	return int(mix64(hash64(id[:])) % uint64(buckets))
+}
+
+// InExperiment reports whether the uuid is part of a rollout to the given
+// percentage, between 0 and 100, of the UUIDs. The assignment is stable: an
+// uuid in a rollout stays in it when the percentage grows.
+func (id UUID) InExperiment(percent float64) bool {
+	// the top 53 bits of the hash mapped to [0, 100).
+	h := mix64(hash64(id[:]))
+	return float64(h>>11)/(1<<53)*100 < percent
+}
+
+// hash64 returns the 64-bit FNV-1a hash of b.
+func hash64(b []byte) uint64 {
+	h := fnv.New64a()
+	_, _ = h.Write(b)
+	return h.Sum64()
+}
+
+// mix64 scrambles the bits of x with the SplitMix64 finalizer, so close
+// values such as consecutive timestamps are spread evenly.
+func mix64(x uint64) uint64 {
+	x ^= x >> 30
+	x *= 0xbf58476d1ce4e5b9
+	x ^= x >> 27
+	x *= 0x94d049bb133111eb
+	x ^= x >> 31
+	return x
+}
+
+// Aggregator computes an order-independent digest of a set of UUIDs, e.g. to
+// detect drift between two stores. The SHA-256 of each added uuid is XORed
+// into an accumulator, so the same UUIDs give the same digest regardless of
+// the order they're added in. Adding an uuid twice cancels it out, hence
+// each uuid of the set must be added once. The zero value is ready to use.
+type Aggregator struct {
+	sum [sha256.Size]byte
+}
+
+// Add incorporates id into the digest.
+func (a *Aggregator) Add(id UUID) {
+	h := sha256.Sum256(id[:])
+	for i := range a.sum {
+		a.sum[i] ^= h[i]
+	}
+}
+
+// Sum returns the digest of the UUIDs added so far.
+func (a *Aggregator) Sum() [32]byte { return a.sum }
+
+// maxPINDigits is the largest number of digits of a PIN, 10^19 fits in 64 bits.
+const maxPINDigits = 19
+
+// PIN returns a stable numeric code of the given number of digits, zero
+// padded, derived from the SHA-256 of the uuid. Support staff can read it
+// out to confirm they're looking at the same record. It's low entropy and
+// must not be used as a security control on its own.
+// It returns an empty string if digits is not between 1 and 19.
+func (id UUID) PIN(digits int) string {
+	if digits < 1 || digits > maxPINDigits {
+		return ""
+	}
+
+	mod := uint64(1)
+	for i := 0; i < digits; i++ {
+		mod *= 10
+	}
+
+	sum := sha256.Sum256(id[:])
+	return fmt.Sprintf("%0*d", digits, binary.BigEndian.Uint64(sum[:8])%mod)
+}
@@ -0,0 +1,182 @@
+package uuid
+
+import (
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestUUID_LocalityHash(t *testing.T) {
+	const shards = 16
+	now := time.Date(2024, time.May, 1, 12, 0, 0, 0, time.UTC)
+
+	// uuids of the same millisecond mostly share a shard.
+	counts := make(map[int]int)
+	for i := 0; i < 1000; i++ {
+		uid := v7At(now)
+		random := must(t, New)
+		copy(uid[9:], random[9:])
+		counts[uid.LocalityHash(shards)]++
+	}
+
+	max := 0
+	for _, n := range counts {
+		if n > max {
+			max = n
+		}
+	}
+
+	if max < 800 {
+		t.Fatal("same millisecond uuids are not sharing a shard:", counts)
+	}
+
+	// uuids of different milliseconds are balanced.
+	assertBalanced(t, shards, 16000, func(i int) int {
+		return v7At(now.Add(time.Duration(i) * time.Millisecond)).LocalityHash(shards)
+	})
+
+	// random uuids are balanced.
+	assertBalanced(t, shards, 16000, func(int) int { return must(t, New).LocalityHash(shards) })
+}
+
+func TestUUID_LocalityHash_Shards(t *testing.T) {
+	uid := must(t, New)
+	if got := uid.LocalityHash(0); got != 0 {
+		t.Fatal("unexpected shard:", got)
+	}
+
+	if got := uid.LocalityHash(1); got != 0 {
+		t.Fatal("unexpected shard:", got)
+	}
+
+	if uid.LocalityHash(7) != uid.LocalityHash(7) {
+		t.Fatal("shard is not stable")
+	}
+}
+
+// assertBalanced asserts that n values of shard are spread evenly, within
+// 25% of the mean, across the given number of buckets.
+func assertBalanced(t *testing.T, buckets, n int, bucket func(i int) int) {
+	t.Helper()
+
+	counts := make([]int, buckets)
+	for i := 0; i < n; i++ {
+		b := bucket(i)
+		if b < 0 || b >= buckets {
+			t.Fatal("unexpected bucket:", b)
+		}
+		counts[b]++
+	}
+
+	mean := n / buckets
+	for b, c := range counts {
+		if c < mean*3/4 || c > mean*5/4 {
+			t.Fatalf("unbalanced bucket %d: %v", b, counts)
+		}
+	}
+}
+
+func TestAggregator(t *testing.T) {
+	ids := make([]UUID, 100)
+	for i := range ids {
+		ids[i] = must(t, New)
+	}
+
+	var forward, backward Aggregator
+	for i := range ids {
+		forward.Add(ids[i])
+		backward.Add(ids[len(ids)-1-i])
+	}
+
+	if forward.Sum() != backward.Sum() {
+		t.Fatal("digest depends on the order")
+	}
+
+	// a single differing uuid changes the digest.
+	var other Aggregator
+	for _, id := range ids[1:] {
+		other.Add(id)
+	}
+	other.Add(ids[0].Increment())
+	if other.Sum() == forward.Sum() {
+		t.Fatal("digest is not sensitive to a differing uuid")
+	}
+
+	var empty Aggregator
+	if empty.Sum() != [32]byte{} {
+		t.Fatal("unexpected empty digest:", empty.Sum())
+	}
+}
+
+func TestUUID_PIN(t *testing.T) {
+	uid := must(t, New)
+	for digits := 1; digits <= 19; digits++ {
+		pin := uid.PIN(digits)
+		if len(pin) != digits {
+			t.Fatal("unexpected pin length:", pin)
+		}
+
+		if _, err := strconv.ParseUint(pin, 10, 64); err != nil {
+			t.Fatal("unexpected non numeric pin:", pin)
+		}
+
+		if pin != uid.PIN(digits) {
+			t.Fatal("pin is not deterministic:", pin)
+		}
+	}
+
+	if uid.PIN(0) != "" || uid.PIN(20) != "" {
+		t.Fatal("unexpected pin for invalid digits")
+	}
+
+	// zero padded and reasonably uniform.
+	assertBalanced(t, 10, 10000, func(int) int {
+		pin := must(t, New).PIN(6)
+		if len(pin) != 6 {
+			t.Fatal("unexpected pin length:", pin)
+		}
+		return int(pin[0] - '0')
+	})
+}
+
+func TestUUID_Bucket(t *testing.T) {
+	uid := must(t, New)
+	if uid.Bucket(10) != uid.Bucket(10) {
+		t.Fatal("bucket is not stable")
+	}
+
+	if uid.Bucket(0) != 0 || uid.Bucket(1) != 0 {
+		t.Fatal("unexpected bucket")
+	}
+
+	assertBalanced(t, 10, 10000, func(int) int { return must(t, New).Bucket(10) })
+
+	// sequential uuids are balanced too.
+	assertBalanced(t, 10, 10000, func(i int) int { return FromInt(uint64(i)).Bucket(10) })
+}
+
+func TestUUID_InExperiment(t *testing.T) {
+	in := 0
+	for i := 0; i < 10000; i++ {
+		uid := must(t, New)
+		if uid.InExperiment(20) != uid.InExperiment(20) {
+			t.Fatal("assignment is not stable")
+		}
+
+		if uid.InExperiment(20) {
+			in++
+
+			if !uid.InExperiment(50) {
+				t.Fatal("uuid should stay in a growing rollout:", uid)
+			}
+		}
+
+		if uid.InExperiment(0) || !uid.InExperiment(100) {
+			t.Fatal("unexpected assignment:", uid)
+		}
+	}
+
+	if in < 1800 || in > 2200 {
+		t.Fatal("unexpected rollout size:", in)
+	}
+}
@@ -0,0 +1,54 @@
+package uuid
+
+// MarshalText implements encoding.TextMarshaler, it returns the canonical
+// string of the uuid, so it's encoded as a string by encoding/json and the
+// other text based encoders.
+func (id UUID) MarshalText() ([]byte, error) {
+	b := make([]byte, 36)
+	encodeHex(b, id)
+	return b, nil
+}
+
+// AppendText implements encoding.TextAppender, it appends the canonical
+// string of the uuid to dst and returns the extended buffer. It doesn't
+// allocate when dst has enough capacity.
+func (id UUID) AppendText(dst []byte) ([]byte, error) {
+	return id.AppendString(dst), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler, it parses the canonical
+// string of a UUID with Parse and returns its error unchanged.
+func (id *UUID) UnmarshalText(b []byte) error {
+	uid, err := Parse(string(b))
+	if err != nil {
+		return err
+	}
+
+	*id = uid
+	return nil
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler, it returns a copy of
+// the 16 bytes of the uuid.
+func (id UUID) MarshalBinary() ([]byte, error) {
+	return id.Bytes(), nil
+}
+
+// AppendBinary implements encoding.BinaryAppender, it appends the 16 bytes
+// of the uuid to dst and returns the extended buffer.
+func (id UUID) AppendBinary(dst []byte) ([]byte, error) {
+	return append(dst, id[:]...), nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler, it copies the given
+// 16 bytes into the uuid. An error wrapping ErrLength is returned if b is
+// not 16 bytes long.
+func (id *UUID) UnmarshalBinary(b []byte) error {
+	uid, err := FromBytes(b)
+	if err != nil {
+		return err
+	}
+
+	*id = uid
+	return nil
+}
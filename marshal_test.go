@@ -0,0 +1,197 @@
+package uuid
+
+import (
+	"bytes"
+	"encoding/gob"
+	"encoding/json"
+	"errors"
+	"testing"
+)
+
+func TestUUID_MarshalText(t *testing.T) {
+	uid := must(t, func() (UUID, error) { return Parse(StaticUUID) })
+	b, err := uid.MarshalText()
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	if string(b) != StaticUUID {
+		t.Fatal("unexpected text:", string(b))
+	}
+
+	var got UUID
+	if err := got.UnmarshalText([]byte("00010203-0405-4607-8809-0A0B0C0D0E0F")); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	if got != uid {
+		t.Fatal("unexpected uuid:", got)
+	}
+}
+
+func TestUUID_AppendText(t *testing.T) {
+	uid := must(t, func() (UUID, error) { return Parse(StaticUUID) })
+	b, err := uid.AppendText([]byte("id="))
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	if string(b) != "id="+StaticUUID {
+		t.Fatal("unexpected text:", string(b))
+	}
+
+	b, err = Nil.AppendText(nil)
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	if string(b) != Nil.String() {
+		t.Fatal("unexpected text:", string(b))
+	}
+}
+
+func TestUUID_UnmarshalText_Errors(t *testing.T) {
+	table := []struct {
+		name string
+		in   string
+		err  error
+	}{
+		{"empty", "", ErrLength},
+		{"invalid chars", "12345678-1234-1234-1234-12345678901g", ErrChar},
+		{"no dashes", "123456781234123412341234567890120000", ErrInvalidDashes},
+	}
+
+	for _, tt := range table {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			uid := Max
+			if err := uid.UnmarshalText([]byte(tt.in)); err != tt.err {
+				t.Fatal("unexpected error:", err)
+			}
+
+			if uid != Max {
+				t.Fatal("unexpected modified uuid:", uid)
+			}
+		})
+	}
+}
+
+func TestUUID_JSON(t *testing.T) {
+	type record struct {
+		ID     UUID  `json:"id"`
+		Parent *UUID `json:"parent"`
+	}
+
+	uid := must(t, New)
+	b, err := json.Marshal(record{ID: uid})
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	if want := `{"id":"` + uid.String() + `","parent":null}`; string(b) != want {
+		t.Fatal("unexpected json:", string(b))
+	}
+
+	var got record
+	if err := json.Unmarshal(b, &got); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	if got.ID != uid || got.Parent != nil {
+		t.Fatal("unexpected record:", got)
+	}
+
+	if err := json.Unmarshal([]byte(`{"id":"not-a-uuid"}`), &got); err == nil {
+		t.Fatal("expected error, got nil")
+	}
+}
+
+func TestUUID_MarshalBinary(t *testing.T) {
+	uid := must(t, func() (UUID, error) { return Parse(StaticUUID) })
+	b, err := uid.MarshalBinary()
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	if !bytes.Equal(b, uid[:]) {
+		t.Fatalf("unexpected bytes: %#x", b)
+	}
+
+	var got UUID
+	if err := got.UnmarshalBinary(b); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	if got != uid {
+		t.Fatal("unexpected uuid:", got)
+	}
+}
+
+func TestUUID_AppendBinary(t *testing.T) {
+	uid := must(t, func() (UUID, error) { return Parse(StaticUUID) })
+	b, err := uid.AppendBinary([]byte{0xff})
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	if !bytes.Equal(b, append([]byte{0xff}, uid[:]...)) {
+		t.Fatalf("unexpected bytes: %x", b)
+	}
+}
+
+func TestUUID_UnmarshalBinary_Errors(t *testing.T) {
+	for _, b := range [][]byte{nil, make([]byte, 15), make([]byte, 17), []byte(StaticUUID)} {
+		uid := Max
+		if err := uid.UnmarshalBinary(b); !errors.Is(err, ErrLength) {
+			t.Fatal("unexpected error:", err)
+		}
+
+		if uid != Max {
+			t.Fatal("unexpected modified uuid:", uid)
+		}
+	}
+}
+
+func TestUUID_Gob(t *testing.T) {
+	type record struct {
+		ID   UUID
+		Name string
+	}
+
+	want := record{ID: must(t, New), Name: "gob"}
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(want); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	var got record
+	if err := gob.NewDecoder(&buf).Decode(&got); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	if got != want {
+		t.Fatal("unexpected record:", got)
+	}
+}
+
+func BenchmarkUUID_AppendText(b *testing.B) {
+	uid := MustParse(StaticUUID)
+	buf := make([]byte, 0, 36)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		if _, err := uid.AppendText(buf[:0]); err != nil {
+			b.Fatal("unexpected error:", err)
+		}
+	}
+}
+
+func BenchmarkUUID_AppendBinary(b *testing.B) {
+	uid := MustParse(StaticUUID)
+	buf := make([]byte, 0, 16)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		if _, err := uid.AppendBinary(buf[:0]); err != nil {
+			b.Fatal("unexpected error:", err)
+		}
+	}
+}
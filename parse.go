@@ -0,0 +1,357 @@
+package uuid
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// ParseVerbose parses a UUID from a string in the same format as Parse, but
+// on failure the error reports the byte offset of the first invalid
+// character or misplaced dash, e.g.:
+//
+//	uuid: invalid character 'g' at position 35
+//
+// The error is a *ParseError carrying the position. It's intended for
+// validating user input where a friendly message matters more than speed.
+func ParseVerbose(s string) (UUID, error) {
+	if len(s) != 36 {
+		return Nil, &ParseError{Input: s, Pos: -1, Err: ErrLength}
+	}
+
+	for i := 0; i < len(s); i++ {
+		if i == 8 || i == 13 || i == 18 || i == 23 {
+			if s[i] != '-' {
+				return Nil, &ParseError{Input: s, Pos: i, Err: ErrInvalidDashes}
+			}
+			continue
+		}
+
+		if hexValues[s[i]] == 0xff {
+			return Nil, &ParseError{Input: s, Pos: i, Err: ErrChar}
+		}
+	}
+
+	return parse(s, hexStartedIndex)
+}
+
+// EqualString reports whether s is the canonical form of the uuid, ignoring
+// the letter case. It compares byte by byte while decoding and stops at the
+// first mismatch, without allocating. Malformed input is never equal.
+func (id UUID) EqualString(s string) bool {
+	if len(s) != 36 || s[8] != '-' || s[13] != '-' || s[18] != '-' || s[23] != '-' {
+		return false
+	}
+
+	for i, start := range hexStartedIndex {
+		v, ok := hexToByte(s[start], s[start+1])
+		if !ok || v != id[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// ParseValidated parses a UUID from a string with Parse, then runs the given
+// domain validation on it. The error of validate is returned unchanged, so
+// callers can inspect it.
+func ParseValidated(s string, validate func(UUID) error) (UUID, error) {
+	uid, err := Parse(s)
+	if err != nil {
+		return Nil, err
+	}
+
+	if err := validate(uid); err != nil {
+		return Nil, err
+	}
+
+	return uid, nil
+}
+
+// ParseValid parses each string with Parse in a best effort manner. The
+// parsed UUIDs are appended to valid and the strings that failed to parse
+// are appended to invalid, both in their original order.
+func ParseValid(strs []string) (valid []UUID, invalid []string) {
+	for _, s := range strs {
+		uid, err := Parse(s)
+		if err != nil {
+			invalid = append(invalid, s)
+			continue
+		}
+
+		valid = append(valid, uid)
+	}
+
+	return valid, invalid
+}
+
+// ScanUUIDs reads one UUID per line from r and parses each line with Parse
+// after trimming the surrounding whitespace. Empty lines are skipped. The
+// errors of the malformed lines are collected with their line number,
+// followed by the read error, if any.
+func ScanUUIDs(r io.Reader) ([]UUID, []error) {
+	var uids []UUID
+	var errs []error
+
+	scanner := bufio.NewScanner(r)
+	for line := 1; scanner.Scan(); line++ {
+		s := strings.TrimSpace(scanner.Text())
+		if s == "" {
+			continue
+		}
+
+		uid, err := Parse(s)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("uuid: line %d: %w", line, err))
+			continue
+		}
+
+		uids = append(uids, uid)
+	}
+
+	if err := scanner.Err(); err != nil {
+		errs = append(errs, err)
+	}
+
+	return uids, errs
+}
+
+// hyphenlessStartedIndex is the index of the first hex digit in each byte of
+// a UUID without dashes.
+var hyphenlessStartedIndex = [16]int{0, 2, 4, 6, 8, 10, 12, 14, 16, 18, 20, 22, 24, 26, 28, 30}
+
+// ParseHyphenless parses a UUID from the compact form of 32 hex digits
+// without dashes, e.g. 6ba7b8109dad11d180b400c04fd430c8. It returns the
+// same errors as Parse: ErrLength if s is not 32 characters long and ErrChar
+// if it contains an invalid hex digit.
+func ParseHyphenless(s string) (UUID, error) {
+	if len(s) != 32 {
+		return Nil, ErrLength
+	}
+
+	return parse(s, hyphenlessStartedIndex)
+}
+
+// ParseSpaced parses a UUID from 32 hex digits separated by spaces, such as
+// the "xxxx xxxx xxxx ..." form exported by spreadsheets. All spaces are
+// removed before parsing, and the remaining content must be exactly 32 hex
+// digits. The error wraps ErrLength or ErrChar, as returned by
+// ParseHyphenless.
+func ParseSpaced(s string) (UUID, error) {
+	uid, err := ParseHyphenless(strings.ReplaceAll(s, " ", ""))
+	if err != nil {
+		return Nil, fmt.Errorf("uuid: invalid space separated UUID %q: %w", s, err)
+	}
+
+	return uid, nil
+}
+
+// ParseBraced parses a UUID in the canonical form, optionally wrapped in
+// curly braces as the GUIDs emitted by .NET, e.g.
+// {6ba7b810-9dad-11d1-80b4-00c04fd430c8}. A single pair of braces is
+// stripped and the content is validated as Parse does. ErrFormat is returned
+// if only one brace is present.
+func ParseBraced(s string) (UUID, error) {
+	opening := strings.HasPrefix(s, "{")
+	closing := strings.HasSuffix(s, "}")
+	if opening != closing {
+		return Nil, ErrFormat
+	}
+
+	if opening {
+		s = s[1 : len(s)-1]
+	}
+	return Parse(s)
+}
+
+// ParseURN parses a UUID in the URN form defined in RFC 4122, that is the
+// canonical form prefixed by urn:uuid:, e.g.
+// urn:uuid:6ba7b810-9dad-11d1-80b4-00c04fd430c8. The prefix is case
+// insensitive and is required, ErrFormat is returned without it. The rest is
+// validated as Parse does.
+func ParseURN(s string) (UUID, error) {
+	if len(s) < len(urnPrefix) || !strings.EqualFold(s[:len(urnPrefix)], urnPrefix) {
+		return Nil, ErrFormat
+	}
+
+	return Parse(s[len(urnPrefix):])
+}
+
+// autoDecoders is the order in which ParseAuto tries the encodings.
+var autoDecoders = []struct {
+	encoding Encoding
+	decode   func(s string) (UUID, error)
+}{
+	{EncodingCanonical, Parse},
+	{EncodingHyphenless, ParseHyphenless},
+	{EncodingBraced, ParseBraced},
+	{EncodingURN, ParseURN},
+	{EncodingBase32, ParseBase32},
+	{EncodingBase64, ParseBase64},
+}
+
+// ParseAuto parses a UUID from a string in any supported encoding: the
+// canonical form, the hyphenless form, the braced form, the URN form,
+// Crockford base32 and URL-safe base64. The decoders are tried in that
+// order and the first success is returned. When all of them fail, the error
+// lists the reason of each attempted encoding.
+func ParseAuto(s string) (UUID, error) {
+	reasons := make([]string, 0, len(autoDecoders))
+	for _, d := range autoDecoders {
+		uid, err := d.decode(s)
+		if err == nil {
+			return uid, nil
+		}
+
+		reasons = append(reasons, d.encoding.String()+": "+err.Error())
+	}
+
+	return Nil, fmt.Errorf("uuid: unrecognized UUID encoding (%s)", strings.Join(reasons, "; "))
+}
+
+// ParseAnyBytes parses a UUID from a byte slice in the canonical, the
+// hyphenless, the braced or the URN form. Unlike ParseAuto(string(b)), it
+// decodes the bytes in place without building an intermediate string.
+// The base32 and base64 encodings are not supported.
+func ParseAnyBytes(b []byte) (UUID, error) {
+	switch len(b) {
+	case 36:
+		return ParseBytes(b)
+	case 32:
+		return parse(b, hyphenlessStartedIndex)
+	case 38:
+		if b[0] != '{' || b[37] != '}' {
+			return Nil, ErrFormat
+		}
+		return ParseAnyBytes(b[1:37])
+	case 45:
+		if !bytes.EqualFold(b[:9], []byte(urnPrefix)) {
+			return Nil, ErrFormat
+		}
+		return ParseAnyBytes(b[9:])
+	default:
+		return Nil, ErrLength
+	}
+}
+
+// maxUnwrapLayers is the maximum number of encoding layers peeled by Unwrap.
+const maxUnwrapLayers = 3
+
+// unwrapEncodings are the base64 variants tried by Unwrap.
+var unwrapEncodings = []*base64.Encoding{
+	base64.StdEncoding,
+	base64.URLEncoding,
+	base64.RawStdEncoding,
+	base64.RawURLEncoding,
+}
+
+// Unwrap parses a UUID that may have been accidentally encoded again by a
+// buggy client, such as the base64 or the hex of its canonical string. It
+// tries ParseAuto and, on failure, peels one layer of hex or base64 encoding
+// and tries again, up to 3 layers. A layer decoding to exactly 16 bytes is
+// taken as the raw UUID. When a layer decodes as both hex and base64, both
+// are tried, the one decoding to 16 bytes first.
+func Unwrap(s string) (UUID, error) {
+	attempts := 0
+	if uid, ok := unwrap(s, maxUnwrapLayers, &attempts); ok {
+		return uid, nil
+	}
+
+	return Nil, fmt.Errorf("uuid: unable to unwrap a UUID from %q after %d attempts", s, attempts)
+}
+
+// unwrap tries ParseAuto on s, then on each layer peeled from s, up to the
+// given number of layers, counting the calls to ParseAuto in attempts.
+func unwrap(s string, layers int, attempts *int) (UUID, bool) {
+	*attempts++
+	if uid, err := ParseAuto(s); err == nil {
+		return uid, true
+	}
+
+	for _, b := range peel(s) {
+		if len(b) == 16 {
+			var uid UUID
+			copy(uid[:], b)
+			return uid, true
+		}
+
+		if layers == 0 {
+			continue
+		}
+
+		if uid, ok := unwrap(string(b), layers-1, attempts); ok {
+			return uid, true
+		}
+	}
+	return Nil, false
+}
+
+// peel decodes one layer of hex or base64 encoding from s, returning every
+// distinct decoding with the ones of 16 bytes, a raw UUID, first.
+func peel(s string) [][]byte {
+	var layers [][]byte
+	add := func(b []byte) {
+		for _, l := range layers {
+			if bytes.Equal(l, b) {
+				return
+			}
+		}
+
+		if len(b) == 16 {
+			layers = append([][]byte{b}, layers...)
+		} else {
+			layers = append(layers, b)
+		}
+	}
+
+	if b, err := hex.DecodeString(s); err == nil {
+		add(b)
+	}
+
+	for _, enc := range unwrapEncodings {
+		if b, err := enc.DecodeString(s); err == nil {
+			add(b)
+		}
+	}
+	return layers
+}
+
+// Template returns the UUID described by the given pattern, a canonical
+// string where each x is a placeholder filled with zero, to write readable
+// fixtures such as "cafexxxx-xxxx-4xxx-8xxx-xxxxxxxxxxxx". The other
+// characters must be the hex digits and dashes of a canonical string.
+func Template(pattern string) (UUID, error) {
+	return TemplateSeq(pattern, 0)
+}
+
+// TemplateSeq is like Template, but fills the placeholders with the hex
+// digits of n, the last placeholder getting the least significant digit, so
+// sequential fixtures can be generated from the same pattern. An error is
+// returned if n doesn't fit in the placeholders.
+func TemplateSeq(pattern string, n uint64) (UUID, error) {
+	if len(pattern) != 36 {
+		return Nil, fmt.Errorf("uuid: incorrect template length %d, expected 36", len(pattern))
+	}
+
+	buf := []byte(pattern)
+	for i := len(buf) - 1; i >= 0; i-- {
+		if buf[i] == 'x' || buf[i] == 'X' {
+			buf[i] = "0123456789abcdef"[n&0x0f]
+			n >>= 4
+		}
+	}
+
+	if n != 0 {
+		return Nil, fmt.Errorf("uuid: sequence doesn't fit in the placeholders of template %s", pattern)
+	}
+
+	uid, err := Parse(string(buf))
+	if err != nil {
+		return Nil, fmt.Errorf("uuid: invalid template %s: %w", pattern, err)
+	}
+	return uid, nil
+}
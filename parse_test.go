@@ -0,0 +1,746 @@
+package uuid
+
+import (
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseVerbose(t *testing.T) {
+	uid, err := ParseVerbose(StaticUUID)
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	if uid.String() != StaticUUID {
+		t.Fatal("unexpected uuid:", uid)
+	}
+}
+
+func TestParseVerbose_Errors(t *testing.T) {
+	table := []struct {
+		name string
+		in   string
+		msg  string
+		pos  int
+		err  error
+	}{
+		{"empty", "", "uuid: incorrect UUID length 0, expected 36", -1, ErrLength},
+		{"long", "12345678-1234-1234-1234-1234567890123", "uuid: incorrect UUID length 37, expected 36", -1, ErrLength},
+		{"invalid last char", "12345678-1234-1234-1234-12345678901g", "uuid: invalid character 'g' at position 35", 35, ErrChar},
+		{"invalid first char", "x2345678-1234-1234-1234-123456789012", "uuid: invalid character 'x' at position 0", 0, ErrChar},
+		{"missing dash", "123456781234-1234-1234-1234567890120", "uuid: expected '-' at position 8, got '1'", 8, ErrInvalidDashes},
+		{"misplaced dash", "1234567-81234-1234-1234-123456789012", "uuid: invalid character '-' at position 7", 7, ErrChar},
+	}
+
+	for _, tt := range table {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			uid, err := ParseVerbose(tt.in)
+			if err == nil {
+				t.Fatal("expected error, got nil")
+			}
+
+			if err.Error() != tt.msg {
+				t.Fatal("unexpected error:", err)
+			}
+
+			var perr *ParseError
+			if !errors.As(err, &perr) || perr.Input != tt.in || perr.Pos != tt.pos || !errors.Is(err, tt.err) {
+				t.Fatalf("unexpected parse error: %#v", perr)
+			}
+
+			if uid != Nil {
+				t.Fatal("unexpected nil uuid:", uid)
+			}
+		})
+	}
+}
+
+func TestParseError_Error(t *testing.T) {
+	table := []struct {
+		name string
+		err  *ParseError
+		msg  string
+	}{
+		{"zero value", &ParseError{}, "uuid: invalid UUID"},
+		{"nil cause", &ParseError{Input: StaticUUID, Pos: 3}, "uuid: invalid UUID"},
+		{"char after input", &ParseError{Input: "x", Pos: 40, Err: ErrChar}, "uuid: invalid UUID character"},
+		{"char negative pos", &ParseError{Input: "x", Pos: -1, Err: ErrChar}, "uuid: invalid UUID character"},
+		{"dashes negative pos", &ParseError{Input: StaticUUID, Pos: -1, Err: ErrInvalidDashes}, "uuid: misplaced UUID dashes"},
+		{"dashes empty input", &ParseError{Pos: 8, Err: ErrInvalidDashes}, "uuid: misplaced UUID dashes"},
+		{"length", &ParseError{Input: "x", Pos: 40, Err: ErrLength}, "uuid: incorrect UUID length 1, expected 36"},
+		{"other cause", &ParseError{Err: ErrFormat}, "uuid: incorrect UUID format"},
+	}
+
+	for _, tt := range table {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.err.Error(); got != tt.msg {
+				t.Fatal("unexpected message:", got)
+			}
+		})
+	}
+}
+
+func TestUUID_EqualString(t *testing.T) {
+	uid := must(t, func() (UUID, error) { return Parse(StaticUUID) })
+
+	table := []struct {
+		name string
+		in   string
+		want bool
+	}{
+		{"equal", StaticUUID, true},
+		{"equal uppercase", strings.ToUpper(StaticUUID), true},
+		{"first byte differs", "10010203-0405-4607-8809-0a0b0c0d0e0f", false},
+		{"last byte differs", "00010203-0405-4607-8809-0a0b0c0d0e0e", false},
+		{"empty", "", false},
+		{"hyphenless", "000102030405460788090a0b0c0d0e0f", false},
+		{"misplaced dash", "000102030-405-4607-8809-0a0b0c0d0e0f", false},
+		{"invalid chars", "00010203-0405-4607-8809-0a0b0c0d0e0g", false},
+	}
+
+	for _, tt := range table {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			if got := uid.EqualString(tt.in); got != tt.want {
+				t.Fatalf("unexpected result: %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func BenchmarkUUID_EqualString(b *testing.B) {
+	uid, _ := Parse(StaticUUID)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		if !uid.EqualString(StaticUUID) {
+			b.Fatal("unexpected not equal uuid")
+		}
+	}
+}
+
+func BenchmarkUUID_EqualString_Parse(b *testing.B) {
+	uid, _ := Parse(StaticUUID)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		other, err := Parse(StaticUUID)
+		if err != nil || other != uid {
+			b.Fatal("unexpected not equal uuid")
+		}
+	}
+}
+
+func TestParseValidated(t *testing.T) {
+	errNotV7 := errors.New("not a v7 uuid")
+	onlyV7 := func(uid UUID) error {
+		if uid[6]>>4 != 7 {
+			return errNotV7
+		}
+		return nil
+	}
+
+	v7 := v7At(time.Now())
+	uid, err := ParseValidated(v7.String(), onlyV7)
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	if uid != v7 {
+		t.Fatal("unexpected uuid:", uid)
+	}
+
+	uid, err = ParseValidated(must(t, New).String(), onlyV7)
+	if err != errNotV7 {
+		t.Fatal("unexpected error:", err)
+	}
+
+	if uid != Nil {
+		t.Fatal("unexpected nil uuid:", uid)
+	}
+
+	called := false
+	_, err = ParseValidated("not-a-uuid", func(UUID) error { called = true; return nil })
+	if err != ErrLength || called {
+		t.Fatal("unexpected error:", err)
+	}
+}
+
+func TestParseValid(t *testing.T) {
+	uid := must(t, New)
+	strs := []string{StaticUUID, "", uid.String(), "not-a-uuid", "12345678-1234-1234-1234-12345678901g"}
+
+	valid, invalid := ParseValid(strs)
+	if len(valid) != 2 || valid[0].String() != StaticUUID || valid[1] != uid {
+		t.Fatal("unexpected valid uuids:", valid)
+	}
+
+	if len(invalid) != 3 || invalid[0] != strs[1] || invalid[1] != strs[3] || invalid[2] != strs[4] {
+		t.Fatal("unexpected invalid strings:", invalid)
+	}
+
+	valid, invalid = ParseValid(nil)
+	if valid != nil || invalid != nil {
+		t.Fatal("unexpected result:", valid, invalid)
+	}
+}
+
+func TestScanUUIDs(t *testing.T) {
+	uid := must(t, New)
+	in := strings.Join([]string{
+		StaticUUID,
+		"",
+		"  " + uid.String() + "\t",
+		"   ",
+		"12345678-1234-1234-1234-12345678901g",
+		strings.ToUpper(StaticUUID),
+	}, "\n")
+
+	uids, errs := ScanUUIDs(strings.NewReader(in))
+	if len(uids) != 3 || uids[0].String() != StaticUUID || uids[1] != uid || uids[2].String() != StaticUUID {
+		t.Fatal("unexpected uuids:", uids)
+	}
+
+	if len(errs) != 1 || !strings.HasPrefix(errs[0].Error(), "uuid: line 5: ") {
+		t.Fatal("unexpected errors:", errs)
+	}
+}
+
+func TestScanUUIDs_ErrorsReader(t *testing.T) {
+	uids, errs := ScanUUIDs(ErrorsReader())
+	if len(uids) != 0 || len(errs) != 0 {
+		t.Fatal("unexpected result:", uids, errs)
+	}
+}
+
+func TestParseSpaced(t *testing.T) {
+	table := []struct {
+		name string
+		in   string
+	}{
+		{"grouped by 4", "0001 0203 0405 4607 8809 0a0b 0c0d 0e0f"},
+		{"surrounding spaces", "  0001 0203 0405 4607 8809 0a0b 0c0d 0e0f  "},
+		{"irregular groups", "00010203 0405  4607 8809 0a0b0c0d0e0f"},
+		{"no spaces", "000102030405460788090a0b0c0d0e0f"},
+	}
+
+	for _, tt := range table {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			uid, err := ParseSpaced(tt.in)
+			if err != nil {
+				t.Fatal("unexpected error:", err)
+			}
+
+			if uid.String() != StaticUUID {
+				t.Fatal("unexpected uuid:", uid)
+			}
+		})
+	}
+}
+
+func TestParseSpaced_Errors(t *testing.T) {
+	table := []struct {
+		name string
+		in   string
+		err  error
+	}{
+		{"empty", "", ErrLength},
+		{"too few hex", "0001 0203 0405 4607 8809 0a0b 0c0d 0e", ErrLength},
+		{"too many hex", "0001 0203 0405 4607 8809 0a0b 0c0d 0e0f 00", ErrLength},
+		{"invalid chars", "0001 0203 0405 4607 8809 0a0b 0c0d 0e0g", ErrChar},
+		{"not hex", "zzzz zzzz zzzz zzzz zzzz zzzz zzzz zzzz", ErrChar},
+		{"dashes", "0001-0203-0405-4607-8809-0a0b-0c0d-0e0f", ErrLength},
+		{"tabs", "0001\t0203 0405 4607 8809 0a0b 0c0d 0e0f", ErrLength},
+	}
+
+	for _, tt := range table {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			uid, err := ParseSpaced(tt.in)
+			if err == nil {
+				t.Fatal("expected error, got nil")
+			}
+
+			if !errors.Is(err, tt.err) || !strings.Contains(err.Error(), strconv.Quote(tt.in)) {
+				t.Fatal("unexpected error:", err)
+			}
+
+			if uid != Nil {
+				t.Fatal("unexpected nil uuid:", uid)
+			}
+		})
+	}
+}
+
+func TestParseHyphenless(t *testing.T) {
+	for _, in := range []string{"000102030405460788090a0b0c0d0e0f", "000102030405460788090A0B0C0D0E0F"} {
+		uid, err := ParseHyphenless(in)
+		if err != nil {
+			t.Fatal("unexpected error:", err)
+		}
+
+		if uid.String() != StaticUUID {
+			t.Fatal("unexpected uuid:", uid)
+		}
+	}
+}
+
+func TestParseHyphenless_Errors(t *testing.T) {
+	table := []struct {
+		name string
+		in   string
+		err  error
+	}{
+		{"empty", "", ErrLength},
+		{"short", "000102030405460788090a0b0c0d0e0", ErrLength},
+		{"long", "000102030405460788090a0b0c0d0e0f0", ErrLength},
+		{"canonical", StaticUUID, ErrLength},
+		{"invalid chars", "000102030405460788090a0b0c0d0e0g", ErrChar},
+		{"dashes", "00010203-04054607-88090a0b0c0d0e", ErrChar},
+	}
+
+	for _, tt := range table {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			uid, err := ParseHyphenless(tt.in)
+			if err != tt.err {
+				t.Fatal("unexpected error:", err)
+			}
+
+			if uid != Nil {
+				t.Fatal("unexpected nil uuid:", uid)
+			}
+		})
+	}
+}
+
+func TestParseBraced(t *testing.T) {
+	table := []struct {
+		name string
+		in   string
+	}{
+		{"braced", "{" + StaticUUID + "}"},
+		{"uppercase", "{00010203-0405-4607-8809-0A0B0C0D0E0F}"},
+		{"bare", StaticUUID},
+	}
+
+	for _, tt := range table {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			uid, err := ParseBraced(tt.in)
+			if err != nil {
+				t.Fatal("unexpected error:", err)
+			}
+
+			if uid.String() != StaticUUID {
+				t.Fatal("unexpected uuid:", uid)
+			}
+		})
+	}
+}
+
+func TestParseBraced_Errors(t *testing.T) {
+	table := []struct {
+		name string
+		in   string
+		err  error
+	}{
+		{"opening brace", "{" + StaticUUID, ErrFormat},
+		{"closing brace", StaticUUID + "}", ErrFormat},
+		{"double braces", "{{" + StaticUUID + "}}", ErrLength},
+		{"empty braces", "{}", ErrLength},
+		{"invalid chars", "{00010203-0405-4607-8809-0a0b0c0d0e0g}", ErrChar},
+		{"misplaced dashes", "{000102030-405-4607-8809-0a0b0c0d0e0f}", ErrInvalidDashes},
+	}
+
+	for _, tt := range table {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			uid, err := ParseBraced(tt.in)
+			if err != tt.err {
+				t.Fatal("unexpected error:", err)
+			}
+
+			if uid != Nil {
+				t.Fatal("unexpected nil uuid:", uid)
+			}
+		})
+	}
+}
+
+func TestParseURN(t *testing.T) {
+	for _, in := range []string{"urn:uuid:" + StaticUUID, "URN:UUID:" + StaticUUID, "urn:uuid:00010203-0405-4607-8809-0A0B0C0D0E0F"} {
+		uid, err := ParseURN(in)
+		if err != nil {
+			t.Fatal("unexpected error:", err)
+		}
+
+		if uid.String() != StaticUUID {
+			t.Fatal("unexpected uuid:", uid)
+		}
+	}
+}
+
+func TestParseURN_Errors(t *testing.T) {
+	table := []struct {
+		name string
+		in   string
+		err  error
+	}{
+		{"empty", "", ErrFormat},
+		{"bare urn", "urn:", ErrFormat},
+		{"bare prefix", "urn:uuid:", ErrLength},
+		{"no prefix", StaticUUID, ErrFormat},
+		{"other namespace", "urn:isbn:" + StaticUUID, ErrFormat},
+		{"short", "urn:uuid:" + StaticUUID[:35], ErrLength},
+		{"invalid chars", "urn:uuid:00010203-0405-4607-8809-0a0b0c0d0e0g", ErrChar},
+		{"braced", "urn:uuid:{" + StaticUUID + "}", ErrLength},
+	}
+
+	for _, tt := range table {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			uid, err := ParseURN(tt.in)
+			if err != tt.err {
+				t.Fatal("unexpected error:", err)
+			}
+
+			if uid != Nil {
+				t.Fatal("unexpected nil uuid:", uid)
+			}
+		})
+	}
+}
+
+func TestParseAuto(t *testing.T) {
+	table := []struct {
+		name string
+		in   string
+	}{
+		{"canonical", StaticUUID},
+		{"canonical uppercase", strings.ToUpper(StaticUUID)},
+		{"hyphenless", "000102030405460788090a0b0c0d0e0f"},
+		{"braced", "{" + StaticUUID + "}"},
+		{"urn", "urn:uuid:" + StaticUUID},
+		{"urn uppercase", "URN:UUID:" + StaticUUID},
+		{"base32", "000G40R40N30F209185GR38E1W"},
+		{"base32 lowercase", "000g40r40n30f209185gr38e1w"},
+		{"base64", "AAECAwQFRgeICQoLDA0ODw"},
+	}
+
+	for _, tt := range table {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			uid, err := ParseAuto(tt.in)
+			if err != nil {
+				t.Fatal("unexpected error:", err)
+			}
+
+			if uid.String() != StaticUUID {
+				t.Fatal("unexpected uuid:", uid)
+			}
+		})
+	}
+}
+
+func TestParseAuto_Errors(t *testing.T) {
+	table := []struct {
+		name string
+		in   string
+	}{
+		{"empty", ""},
+		{"canonical invalid char", "00010203-0405-4607-8809-0a0b0c0d0e0g"},
+		{"braced unbalanced", "{" + StaticUUID},
+		{"urn wrong prefix", "urn:uid::" + StaticUUID},
+		{"base32 excluded letter", strings.Repeat("U", 26)},
+		{"base64 invalid char", strings.Repeat("+", 22)},
+	}
+
+	for _, tt := range table {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			uid, err := ParseAuto(tt.in)
+			if err == nil {
+				t.Fatal("expected error, got nil")
+			}
+
+			// the error lists every attempted encoding.
+			for _, d := range autoDecoders {
+				if !strings.Contains(err.Error(), d.encoding.String()+": ") {
+					t.Fatalf("error does not mention %s: %v", d.encoding, err)
+				}
+			}
+
+			if uid != Nil {
+				t.Fatal("unexpected nil uuid:", uid)
+			}
+		})
+	}
+}
+
+func TestParseAnyBytes(t *testing.T) {
+	table := []struct {
+		name string
+		in   string
+	}{
+		{"canonical", StaticUUID},
+		{"canonical uppercase", strings.ToUpper(StaticUUID)},
+		{"hyphenless", "000102030405460788090a0b0c0d0e0f"},
+		{"braced", "{" + StaticUUID + "}"},
+		{"urn", "urn:uuid:" + StaticUUID},
+		{"urn uppercase", "URN:UUID:" + StaticUUID},
+	}
+
+	for _, tt := range table {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			uid, err := ParseAnyBytes([]byte(tt.in))
+			if err != nil {
+				t.Fatal("unexpected error:", err)
+			}
+
+			if uid.String() != StaticUUID {
+				t.Fatal("unexpected uuid:", uid)
+			}
+		})
+	}
+}
+
+func TestParseAnyBytes_Errors(t *testing.T) {
+	table := []struct {
+		name string
+		in   string
+	}{
+		{"empty", ""},
+		{"canonical invalid char", "00010203-0405-4607-8809-0a0b0c0d0e0g"},
+		{"canonical misplaced dash", "000102030-405-4607-8809-0a0b0c0d0e0f"},
+		{"hyphenless invalid char", strings.Repeat("g", 32)},
+		{"braced unbalanced", "{" + StaticUUID + ")"},
+		{"braced hyphenless", "{000102030405460788090a0b0c0d0e0f}xxxx"},
+		{"urn wrong prefix", "urn:uid::" + StaticUUID},
+		{"base64", "AAECAwQFRgeICQoLDA0ODw"},
+	}
+
+	for _, tt := range table {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			uid, err := ParseAnyBytes([]byte(tt.in))
+			if err == nil {
+				t.Fatal("expected error, got nil")
+			}
+
+			if uid != Nil {
+				t.Fatal("unexpected nil uuid:", uid)
+			}
+		})
+	}
+}
+
+func FuzzParseAnyBytes(f *testing.F) {
+	f.Add(StaticUUID)
+	f.Add("000102030405460788090a0b0c0d0e0f")
+	f.Add("{" + StaticUUID + "}")
+	f.Add("urn:uuid:" + StaticUUID)
+	f.Add("AAECAwQFRgeICQoLDA0ODw")
+	f.Fuzz(func(t *testing.T, s string) {
+		uid, err := ParseAnyBytes([]byte(s))
+		auto, autoErr := ParseAuto(s)
+		if err == nil && (autoErr != nil || uid != auto) {
+			t.Fatalf("ParseAnyBytes(%q) = %s, ParseAuto = %s, %v", s, uid, auto, autoErr)
+		}
+
+		// both agree on the textual forms, base32 and base64 have other lengths.
+		textual := len(s) == 32 || len(s) == 36 || len(s) == 38 || len(s) == 45
+		if err != nil && autoErr == nil && textual {
+			t.Fatalf("ParseAnyBytes(%q) failed: %v, ParseAuto = %s", s, err, auto)
+		}
+	})
+}
+
+func BenchmarkParseAnyBytes(b *testing.B) {
+	in := []byte("urn:uuid:" + StaticUUID)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		if _, err := ParseAnyBytes(in); err != nil {
+			b.Fatal("unexpected error:", err)
+		}
+	}
+}
+
+func BenchmarkParseAnyBytes_ParseAuto(b *testing.B) {
+	in := []byte("urn:uuid:" + StaticUUID)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		if _, err := ParseAuto(string(in)); err != nil {
+			b.Fatal("unexpected error:", err)
+		}
+	}
+}
+
+func TestUnwrap(t *testing.T) {
+	raw := must(t, func() (UUID, error) { return Parse(StaticUUID) })
+	b64 := base64.StdEncoding.EncodeToString
+	hx := hex.EncodeToString
+
+	table := []struct {
+		name string
+		in   string
+	}{
+		{"canonical", StaticUUID},
+		{"base64 url", "AAECAwQFRgeICQoLDA0ODw"},
+		{"base64 of canonical", b64([]byte(StaticUUID))},
+		{"hex of canonical", hx([]byte(StaticUUID))},
+		{"base64 of base64", b64([]byte(b64([]byte(StaticUUID))))},
+		{"hex of base64 url", hx([]byte("AAECAwQFRgeICQoLDA0ODw"))},
+		{"padded base64 of raw bytes", b64(raw[:])},
+		{"raw url base64 of urn", base64.RawURLEncoding.EncodeToString([]byte("urn:uuid:" + StaticUUID))},
+	}
+
+	for _, tt := range table {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			uid, err := Unwrap(tt.in)
+			if err != nil {
+				t.Fatal("unexpected error:", err)
+			}
+
+			if uid != raw {
+				t.Fatal("unexpected uuid:", uid)
+			}
+		})
+	}
+}
+
+func TestUnwrap_Ambiguous(t *testing.T) {
+	// the base64 only uses hex digits and has non-zero trailing bits, so it's
+	// rejected by ParseAuto, and its hex decoding isn't a UUID.
+	in := "ABCDEFabcdef0123456789"
+	if _, err := ParseAuto(in); err == nil {
+		t.Fatal("expected error, got nil")
+	}
+
+	if _, err := hex.DecodeString(in); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	var want UUID
+	if _, err := base64.RawStdEncoding.Decode(want[:], []byte(in)); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	uid, err := Unwrap(in)
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	if uid != want {
+		t.Fatal("unexpected uuid:", uid)
+	}
+}
+
+func TestUnwrap_Errors(t *testing.T) {
+	b64 := func(s string) string { return base64.StdEncoding.EncodeToString([]byte(s)) }
+	table := []struct {
+		name string
+		in   string
+	}{
+		{"empty", ""},
+		{"garbage", "not a uuid!"},
+		{"base64 of garbage", b64("not a uuid")},
+		{"too many layers", b64(b64(b64(b64(StaticUUID))))},
+	}
+
+	for _, tt := range table {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			uid, err := Unwrap(tt.in)
+			if err == nil {
+				t.Fatal("expected error, got nil")
+			}
+
+			if uid != Nil {
+				t.Fatal("unexpected nil uuid:", uid)
+			}
+
+			// the error reports the original input, not the peeled bytes.
+			if !strings.Contains(err.Error(), strconv.Quote(tt.in)) || strings.ContainsRune(err.Error(), 0) {
+				t.Fatal("unexpected error:", err)
+			}
+		})
+	}
+}
+
+func TestTemplate(t *testing.T) {
+	table := []struct {
+		pattern string
+		n       uint64
+		want    string
+	}{
+		{"cafexxxx-xxxx-xxxx-xxxx-xxxxxxxxxxx1", 0, "cafe0000-0000-0000-0000-000000000001"},
+		{"cafe0000-0000-0000-0000-000000000001", 0, "cafe0000-0000-0000-0000-000000000001"},
+		{"cafexxxx-xxxx-4xxx-8xxx-xxxxxxxxxxxx", 0, "cafe0000-0000-4000-8000-000000000000"},
+		{"cafexxxx-xxxx-4xxx-8xxx-xxxxxxxxxxxx", 1, "cafe0000-0000-4000-8000-000000000001"},
+		{"cafexxxx-xxxx-4xxx-8xxx-xxxxxxxxxxxx", 0x1234, "cafe0000-0000-4000-8000-000000001234"},
+		{"CAFEXXXX-0000-0000-0000-0000000000XX", 0x1ab, "cafe0001-0000-0000-0000-0000000000ab"},
+		{"xxxxxxxx-xxxx-xxxx-xxxx-xxxxxxxxxxxx", 1<<64 - 1, "00000000-0000-0000-ffff-ffffffffffff"},
+	}
+
+	for _, tt := range table {
+		tt := tt
+		t.Run(tt.pattern, func(t *testing.T) {
+			uid, err := TemplateSeq(tt.pattern, tt.n)
+			if err != nil {
+				t.Fatal("unexpected error:", err)
+			}
+
+			if uid.String() != tt.want {
+				t.Fatal("unexpected uuid:", uid)
+			}
+		})
+	}
+
+	uid, err := Template("cafexxxx-xxxx-xxxx-xxxx-xxxxxxxxxxx1")
+	if err != nil || uid.String() != "cafe0000-0000-0000-0000-000000000001" {
+		t.Fatal("unexpected uuid:", uid, err)
+	}
+}
+
+func TestTemplate_Errors(t *testing.T) {
+	table := []struct {
+		name    string
+		pattern string
+		n       uint64
+		err     error
+	}{
+		{"empty", "", 0, nil},
+		{"short", "cafexxxx-xxxx-xxxx-xxxx-xxxxxxxxxxx", 0, nil},
+		{"long", "cafexxxx-xxxx-xxxx-xxxx-xxxxxxxxxxxxx", 0, nil},
+		{"bad char", "cafexxxx-xxxx-xxxx-xxxx-xxxxxxxxxxxy", 0, ErrChar},
+		{"bad dashes", "cafexxxxx-xxx-xxxx-xxxx-xxxxxxxxxxxx", 0, ErrFormat},
+		{"sequence overflow", "cafe0000-0000-0000-0000-0000000000xx", 0x100, nil},
+	}
+
+	for _, tt := range table {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			uid, err := TemplateSeq(tt.pattern, tt.n)
+			if err == nil {
+				t.Fatal("expected error, got nil")
+			}
+
+			if tt.err != nil && !errors.Is(err, tt.err) {
+				t.Fatal("unexpected error:", err)
+			}
+
+			if uid != Nil {
+				t.Fatal("unexpected nil uuid:", uid)
+			}
+		})
+	}
+}
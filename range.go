@@ -0,0 +1,147 @@
+package uuid
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"math/big"
+	"math/bits"
+)
+
+// PrefixBounds returns the smallest and the largest UUID whose hexadecimal
+// form (without dashes) starts with the given prefix. The remaining nibbles
+// of lo are set to 0 and the remaining nibbles of hi are set to f, so both
+// can be used as inclusive bounds when range-scanning a sorted UUID index.
+//
+// The prefix must only contain hex digits and must not be longer than 32
+// characters.
+func PrefixBounds(hexPrefix string) (lo, hi UUID, err error) {
+	if len(hexPrefix) > 32 {
+		return Nil, Nil, fmt.Errorf("uuid: prefix is longer than 32 characters: %s", hexPrefix)
+	}
+
+	hi = Max
+
+	for i := 0; i < len(hexPrefix); i++ {
+		v := hexValues[hexPrefix[i]]
+		if v == 0xff {
+			return Nil, Nil, fmt.Errorf("uuid: invalid hex prefix: %s", hexPrefix)
+		}
+
+		// even index is the high nibble of the byte, odd index is the low one.
+		if i%2 == 0 {
+			lo[i/2] = (lo[i/2] & 0x0f) | v<<4
+			hi[i/2] = (hi[i/2] & 0x0f) | v<<4
+		} else {
+			lo[i/2] = (lo[i/2] & 0xf0) | v
+			hi[i/2] = (hi[i/2] & 0xf0) | v
+		}
+	}
+
+	return lo, hi, nil
+}
+
+// Equal reports whether id and other are the same UUID.
+func (id UUID) Equal(other UUID) bool {
+	return id == other
+}
+
+// Compare compares the bytes of id and other, returning -1, 0 or +1. It's
+// the order of the UUIDs as big-endian 128-bit integers, and as canonical
+// strings. For v6 and v7 UUIDs, it's also the order of their timestamps.
+func (id UUID) Compare(other UUID) int {
+	return bytes.Compare(id[:], other[:])
+}
+
+// IsAdjacent reports whether id and other differ by exactly one when both
+// are interpreted as big-endian 128-bit unsigned integers.
+func (id UUID) IsAdjacent(other UUID) bool {
+	a, b := id, other
+	if greater128(b, a) {
+		a, b = b, a
+	}
+
+	hi, lo := sub128(a, b)
+	return hi == 0 && lo == 1
+}
+
+// Increment returns the uuid incremented by one as a 128-bit integer, with
+// the carry propagated across all bytes. Incrementing the largest possible
+// value (all bits set) wraps around to Nil.
+func (id UUID) Increment() UUID {
+	hi, lo := toUint128(id)
+	lo, carry := bits.Add64(lo, 1, 0)
+	hi, _ = bits.Add64(hi, 0, carry)
+	return fromUint128(hi, lo)
+}
+
+// Decrement returns the uuid decremented by one as a 128-bit integer, with
+// the borrow propagated across all bytes. Decrementing Nil wraps around to
+// the largest possible value (all bits set).
+func (id UUID) Decrement() UUID {
+	hi, lo := toUint128(id)
+	lo, borrow := bits.Sub64(lo, 1, 0)
+	hi, _ = bits.Sub64(hi, 0, borrow)
+	return fromUint128(hi, lo)
+}
+
+// Midpoint returns the uuid whose 128-bit integer value is the average of
+// lo and hi, rounded down. The average is computed as lo + (hi-lo)/2, so it
+// never overflows. When lo is greater than hi the arguments are swapped,
+// hence the result always sorts between the two inputs.
+func Midpoint(lo, hi UUID) UUID {
+	if greater128(lo, hi) {
+		lo, hi = hi, lo
+	}
+
+	dhi, dlo := sub128(hi, lo)
+	dlo = dlo>>1 | dhi<<63
+	dhi >>= 1
+
+	lhi, llo := toUint128(lo)
+	mlo, carry := bits.Add64(llo, dlo, 0)
+	mhi, _ := bits.Add64(lhi, dhi, carry)
+	return fromUint128(mhi, mlo)
+}
+
+// CountBetween returns the number of distinct UUIDs in [lo, hi) as 128-bit
+// integers, that is hi - lo. It returns zero if lo is not less than hi.
+func CountBetween(lo, hi UUID) *big.Int {
+	if !greater128(hi, lo) {
+		return new(big.Int)
+	}
+
+	dhi, dlo := sub128(hi, lo)
+	n := new(big.Int).SetUint64(dhi)
+	n.Lsh(n, 64)
+	return n.Or(n, new(big.Int).SetUint64(dlo))
+}
+
+// toUint128 splits the uuid into the high and the low 64-bit halves.
+func toUint128(id UUID) (hi, lo uint64) {
+	return binary.BigEndian.Uint64(id[:8]), binary.BigEndian.Uint64(id[8:])
+}
+
+// fromUint128 joins the high and the low 64-bit halves into an uuid.
+func fromUint128(hi, lo uint64) UUID {
+	var id UUID
+	binary.BigEndian.PutUint64(id[:8], hi)
+	binary.BigEndian.PutUint64(id[8:], lo)
+	return id
+}
+
+// greater128 reports whether a is greater than b as 128-bit integers.
+func greater128(a, b UUID) bool {
+	ahi, alo := toUint128(a)
+	bhi, blo := toUint128(b)
+	return ahi > bhi || (ahi == bhi && alo > blo)
+}
+
+// sub128 returns a - b as 128-bit integers, wrapping on underflow.
+func sub128(a, b UUID) (hi, lo uint64) {
+	ahi, alo := toUint128(a)
+	bhi, blo := toUint128(b)
+	lo, borrow := bits.Sub64(alo, blo, 0)
+	hi, _ = bits.Sub64(ahi, bhi, borrow)
+	return hi, lo
+}
@@ -0,0 +1,281 @@
+package uuid
+
+import (
+	"math/big"
+	"strings"
+	"testing"
+)
+
+func TestPrefixBounds(t *testing.T) {
+	table := []struct {
+		name   string
+		prefix string
+		lo     string
+		hi     string
+	}{
+		{"empty", "", "00000000-0000-0000-0000-000000000000", "ffffffff-ffff-ffff-ffff-ffffffffffff"},
+		{"even", "cafe", "cafe0000-0000-0000-0000-000000000000", "cafeffff-ffff-ffff-ffff-ffffffffffff"},
+		{"odd", "abc", "abc00000-0000-0000-0000-000000000000", "abcfffff-ffff-ffff-ffff-ffffffffffff"},
+		{"uppercase", "ABC", "abc00000-0000-0000-0000-000000000000", "abcfffff-ffff-ffff-ffff-ffffffffffff"},
+		{"full", "000102030405460788090a0b0c0d0e0f", StaticUUID, StaticUUID},
+	}
+
+	for _, tt := range table {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			lo, hi, err := PrefixBounds(tt.prefix)
+			if err != nil {
+				t.Fatal("unexpected error:", err)
+			}
+
+			if lo.String() != tt.lo {
+				t.Fatal("unexpected lo:", lo)
+			}
+
+			if hi.String() != tt.hi {
+				t.Fatal("unexpected hi:", hi)
+			}
+		})
+	}
+}
+
+func TestPrefixBounds_Errors(t *testing.T) {
+	table := []struct {
+		name   string
+		prefix string
+	}{
+		{"too long", strings.Repeat("a", 33)},
+		{"invalid chars", "cafg"},
+		{"dashes", "cafe-"},
+	}
+
+	for _, tt := range table {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			lo, hi, err := PrefixBounds(tt.prefix)
+			if err == nil {
+				t.Fatal("expected error, got nil")
+			}
+
+			if lo != Nil || hi != Nil {
+				t.Fatal("unexpected non nil bounds:", lo, hi)
+			}
+		})
+	}
+}
+
+func TestUUID_IsAdjacent(t *testing.T) {
+	table := []struct {
+		name string
+		a    string
+		b    string
+		want bool
+	}{
+		{"plus one", "00000000-0000-0000-0000-000000000000", "00000000-0000-0000-0000-000000000001", true},
+		{"minus one", "00000000-0000-0000-0000-000000000001", "00000000-0000-0000-0000-000000000000", true},
+		{"carry byte", "00000000-0000-0000-0000-0000000000ff", "00000000-0000-0000-0000-000000000100", true},
+		{"carry half", "00000000-0000-0000-ffff-ffffffffffff", "00000000-0000-0001-0000-000000000000", true},
+		{"carry half reversed", "00000000-0000-0001-0000-000000000000", "00000000-0000-0000-ffff-ffffffffffff", true},
+		{"equal", StaticUUID, StaticUUID, false},
+		{"two apart", "00000000-0000-0000-0000-000000000000", "00000000-0000-0000-0000-000000000002", false},
+		{"same low half", "00000000-0000-0000-0000-000000000001", "00000000-0000-0001-0000-000000000001", false},
+		{"no wrap around", "ffffffff-ffff-ffff-ffff-ffffffffffff", "00000000-0000-0000-0000-000000000000", false},
+	}
+
+	for _, tt := range table {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			a := must(t, func() (UUID, error) { return Parse(tt.a) })
+			b := must(t, func() (UUID, error) { return Parse(tt.b) })
+			if got := a.IsAdjacent(b); got != tt.want {
+				t.Fatalf("unexpected result: %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestUUID_Increment(t *testing.T) {
+	table := []struct {
+		name string
+		in   string
+		want string
+	}{
+		{"nil", "00000000-0000-0000-0000-000000000000", "00000000-0000-0000-0000-000000000001"},
+		{"no carry", StaticUUID, "00010203-0405-4607-8809-0a0b0c0d0e10"},
+		{"carry byte", "00000000-0000-0000-0000-0000000000ff", "00000000-0000-0000-0000-000000000100"},
+		{"carry half", "00000000-0000-0000-ffff-ffffffffffff", "00000000-0000-0001-0000-000000000000"},
+		{"wrap around", "ffffffff-ffff-ffff-ffff-ffffffffffff", "00000000-0000-0000-0000-000000000000"},
+	}
+
+	for _, tt := range table {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			uid := must(t, func() (UUID, error) { return Parse(tt.in) })
+			if got := uid.Increment(); got.String() != tt.want {
+				t.Fatal("unexpected uuid:", got)
+			}
+		})
+	}
+}
+
+func TestUUID_Decrement(t *testing.T) {
+	table := []struct {
+		name string
+		in   string
+		want string
+	}{
+		{"one", "00000000-0000-0000-0000-000000000001", "00000000-0000-0000-0000-000000000000"},
+		{"no borrow", StaticUUID, "00010203-0405-4607-8809-0a0b0c0d0e0e"},
+		{"borrow byte", "00000000-0000-0000-0000-000000000100", "00000000-0000-0000-0000-0000000000ff"},
+		{"borrow half", "00000000-0000-0001-0000-000000000000", "00000000-0000-0000-ffff-ffffffffffff"},
+		{"wrap around", "00000000-0000-0000-0000-000000000000", "ffffffff-ffff-ffff-ffff-ffffffffffff"},
+	}
+
+	for _, tt := range table {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			uid := must(t, func() (UUID, error) { return Parse(tt.in) })
+			if got := uid.Decrement(); got.String() != tt.want {
+				t.Fatal("unexpected uuid:", got)
+			}
+		})
+	}
+}
+
+func TestUUID_IncrementDecrement(t *testing.T) {
+	uid := must(t, New)
+	if uid.Increment().Decrement() != uid {
+		t.Fatal("unexpected uuid:", uid.Increment().Decrement())
+	}
+
+	if !uid.Increment().IsAdjacent(uid) {
+		t.Fatal("incremented uuid should be adjacent:", uid)
+	}
+}
+
+func TestMidpoint(t *testing.T) {
+	table := []struct {
+		name string
+		lo   string
+		hi   string
+		want string
+	}{
+		{"equal", StaticUUID, StaticUUID, StaticUUID},
+		{"small", "00000000-0000-0000-0000-000000000000", "00000000-0000-0000-0000-00000000000a", "00000000-0000-0000-0000-000000000005"},
+		{"round down", "00000000-0000-0000-0000-000000000000", "00000000-0000-0000-0000-000000000001", "00000000-0000-0000-0000-000000000000"},
+		{"carry half", "00000000-0000-0000-0000-000000000000", "00000000-0000-0001-0000-000000000000", "00000000-0000-0000-8000-000000000000"},
+		{"no overflow", "ffffffff-ffff-ffff-ffff-fffffffffffd", "ffffffff-ffff-ffff-ffff-ffffffffffff", "ffffffff-ffff-ffff-ffff-fffffffffffe"},
+		{"full range", "00000000-0000-0000-0000-000000000000", "ffffffff-ffff-ffff-ffff-ffffffffffff", "7fffffff-ffff-ffff-ffff-ffffffffffff"},
+		{"swapped", "00000000-0000-0000-0000-00000000000a", "00000000-0000-0000-0000-000000000000", "00000000-0000-0000-0000-000000000005"},
+	}
+
+	for _, tt := range table {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			lo := must(t, func() (UUID, error) { return Parse(tt.lo) })
+			hi := must(t, func() (UUID, error) { return Parse(tt.hi) })
+			if got := Midpoint(lo, hi); got.String() != tt.want {
+				t.Fatal("unexpected uuid:", got)
+			}
+		})
+	}
+}
+
+func TestMidpoint_Between(t *testing.T) {
+	// takes 100 random samples.
+	for i := 0; i < 100; i++ {
+		lo, hi := must(t, New), must(t, New)
+		if greater128(lo, hi) {
+			lo, hi = hi, lo
+		}
+
+		mid := Midpoint(lo, hi)
+		if greater128(lo, mid) || greater128(mid, hi) {
+			t.Fatalf("midpoint %s is not between %s and %s", mid, lo, hi)
+		}
+	}
+}
+
+func TestCountBetween(t *testing.T) {
+	uid := must(t, New)
+	table := []struct {
+		name string
+		lo   UUID
+		hi   UUID
+		want string
+	}{
+		{"equal", uid, uid, "0"},
+		{"adjacent", uid, uid.Increment(), "1"},
+		{"reversed", uid.Increment(), uid, "0"},
+		{"carry half", FromInt(0xffffffffffffffff), FromInt(0xffffffffffffffff).Increment().Increment(), "2"},
+		{"full range", Nil, Max, "340282366920938463463374607431768211455"},
+		{"upper half", prefixHi(t, "7"), Max, "170141183460469231731687303715884105728"},
+	}
+
+	for _, tt := range table {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			want, _ := new(big.Int).SetString(tt.want, 10)
+			if got := CountBetween(tt.lo, tt.hi); got.Cmp(want) != 0 {
+				t.Fatal("unexpected count:", got)
+			}
+		})
+	}
+}
+
+// prefixHi returns the upper bound of the given hex prefix.
+func prefixHi(t *testing.T, prefix string) UUID {
+	_, hi, err := PrefixBounds(prefix)
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	return hi
+}
+
+func TestUUID_Compare(t *testing.T) {
+	table := []struct {
+		name string
+		a    UUID
+		b    UUID
+		want int
+	}{
+		{"equal", FromInt(1), FromInt(1), 0},
+		{"less", FromInt(1), FromInt(2), -1},
+		{"greater", FromInt(2), FromInt(1), 1},
+		{"high byte first", MustParse("01000000-0000-0000-0000-000000000000"), MustParse("00ffffff-ffff-ffff-ffff-ffffffffffff"), 1},
+		{"nil and max", Nil, Max, -1},
+	}
+
+	for _, tt := range table {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.a.Compare(tt.b); got != tt.want {
+				t.Fatalf("unexpected result: %d, want %d", got, tt.want)
+			}
+
+			if got := tt.a.Equal(tt.b); got != (tt.want == 0) {
+				t.Fatal("unexpected equality:", got)
+			}
+		})
+	}
+}
+
+func TestUUID_Compare_Order(t *testing.T) {
+	// takes 100 random samples.
+	for i := 0; i < 100; i++ {
+		a, b := must(t, New), must(t, New)
+		if got, want := a.Compare(b), strings.Compare(a.String(), b.String()); got != want {
+			t.Fatalf("unexpected result: %d, string order is %d", got, want)
+		}
+	}
+
+	v7 := NewV7Generator(SecureReader)
+	prev := must(t, v7.NewUUID)
+	for i := 0; i < 100; i++ {
+		uid := must(t, v7.NewUUID)
+		if uid.Compare(prev) != 1 {
+			t.Fatalf("uuid is not increasing: %s <= %s", uid, prev)
+		}
+		prev = uid
+	}
+}
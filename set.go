@@ -0,0 +1,130 @@
+package uuid
+
+import "sort"
+
+// Set is a set of UUIDs. The zero value is a nil set which can be read
+// from, but must be created with NewSet or make before adding to it.
+type Set map[UUID]struct{}
+
+// NewSet creates a new set containing the given UUIDs.
+func NewSet(ids ...UUID) Set {
+	s := make(Set, len(ids))
+	for _, id := range ids {
+		s.Add(id)
+	}
+	return s
+}
+
+// Add adds id to the set.
+func (s Set) Add(id UUID) { s[id] = struct{}{} }
+
+// Has reports whether id is in the set.
+func (s Set) Has(id UUID) bool {
+	_, ok := s[id]
+	return ok
+}
+
+// Remove removes id from the set, it's a no-op if id is not in the set.
+func (s Set) Remove(id UUID) { delete(s, id) }
+
+// Union returns a new set with the UUIDs in s or in other.
+func (s Set) Union(other Set) Set {
+	u := make(Set, len(s)+len(other))
+	for id := range s {
+		u.Add(id)
+	}
+	for id := range other {
+		u.Add(id)
+	}
+	return u
+}
+
+// Intersect returns a new set with the UUIDs in both s and other.
+func (s Set) Intersect(other Set) Set {
+	small, large := s, other
+	if len(small) > len(large) {
+		small, large = large, small
+	}
+
+	i := make(Set)
+	for id := range small {
+		if large.Has(id) {
+			i.Add(id)
+		}
+	}
+	return i
+}
+
+// Difference returns a new set with the UUIDs in s but not in other.
+func (s Set) Difference(other Set) Set {
+	d := make(Set)
+	for id := range s {
+		if !other.Has(id) {
+			d.Add(id)
+		}
+	}
+	return d
+}
+
+// Slice returns the UUIDs of the set sorted by their bytes.
+func (s Set) Slice() []UUID {
+	ids := make([]UUID, 0, len(s))
+	for id := range s {
+		ids = append(ids, id)
+	}
+
+	sort.Slice(ids, func(i, j int) bool { return ids[i].Compare(ids[j]) < 0 })
+	return ids
+}
+
+// Merge returns a new sorted slice with the UUIDs of a and b, with the equal
+// UUIDs kept once. Both a and b must already be sorted by their bytes, e.g.
+// with Set.Slice, the merge is done in a single pass in O(len(a)+len(b)).
+func Merge(a, b []UUID) []UUID {
+	merged := make([]UUID, 0, len(a)+len(b))
+	add := func(id UUID) {
+		if n := len(merged); n == 0 || merged[n-1] != id {
+			merged = append(merged, id)
+		}
+	}
+
+	i, j := 0, 0
+	for i < len(a) && j < len(b) {
+		if a[i].Compare(b[j]) <= 0 {
+			add(a[i])
+			i++
+		} else {
+			add(b[j])
+			j++
+		}
+	}
+
+	for ; i < len(a); i++ {
+		add(a[i])
+	}
+	for ; j < len(b); j++ {
+		add(b[j])
+	}
+	return merged
+}
+
+// UUIDs is a slice of UUIDs implementing sort.Interface, sorted by their
+// bytes as UUID.Compare does.
+type UUIDs []UUID
+
+func (u UUIDs) Len() int           { return len(u) }
+func (u UUIDs) Less(i, j int) bool { return u[i].Compare(u[j]) < 0 }
+func (u UUIDs) Swap(i, j int)      { u[i], u[j] = u[j], u[i] }
+
+// Sort sorts the UUIDs in place by their bytes. For v6 and v7 UUIDs it's the
+// creation order.
+func (u UUIDs) Sort() { sort.Sort(u) }
+
+// Strings returns the canonical strings of the UUIDs, in the same order.
+func (u UUIDs) Strings() []string {
+	strs := make([]string, len(u))
+	for i, id := range u {
+		strs[i] = id.String()
+	}
+	return strs
+}
@@ -0,0 +1,212 @@
+package uuid
+
+import (
+	"bytes"
+	"math/rand"
+	"sort"
+	"testing"
+)
+
+func TestSet(t *testing.T) {
+	a, b := must(t, New), must(t, New)
+
+	s := NewSet(a)
+	if !s.Has(a) || s.Has(b) || len(s) != 1 {
+		t.Fatal("unexpected set:", s)
+	}
+
+	s.Add(b)
+	s.Add(b)
+	if !s.Has(b) || len(s) != 2 {
+		t.Fatal("unexpected set:", s)
+	}
+
+	s.Remove(a)
+	s.Remove(a)
+	if s.Has(a) || len(s) != 1 {
+		t.Fatal("unexpected set:", s)
+	}
+
+	var empty Set
+	if empty.Has(a) || len(empty.Slice()) != 0 {
+		t.Fatal("unexpected empty set:", empty)
+	}
+	empty.Remove(a)
+}
+
+func TestSet_Union(t *testing.T) {
+	a, b, c := must(t, New), must(t, New), must(t, New)
+	s1, s2 := NewSet(a, b), NewSet(b, c)
+
+	u := s1.Union(s2)
+	if len(u) != 3 || !u.Has(a) || !u.Has(b) || !u.Has(c) {
+		t.Fatal("unexpected union:", u)
+	}
+
+	if len(s1) != 2 || len(s2) != 2 {
+		t.Fatal("union must not modify the operands:", s1, s2)
+	}
+
+	if u := s1.Union(s1); len(u) != 2 || !u.Has(a) || !u.Has(b) {
+		t.Fatal("unexpected self union:", u)
+	}
+
+	if u := s1.Union(nil); len(u) != 2 {
+		t.Fatal("unexpected union with empty set:", u)
+	}
+}
+
+func TestSet_Intersect(t *testing.T) {
+	a, b, c := must(t, New), must(t, New), must(t, New)
+	s1, s2 := NewSet(a, b), NewSet(b, c)
+
+	if i := s1.Intersect(s2); len(i) != 1 || !i.Has(b) {
+		t.Fatal("unexpected intersection:", i)
+	}
+
+	if i := s2.Intersect(s1); len(i) != 1 || !i.Has(b) {
+		t.Fatal("unexpected intersection:", i)
+	}
+
+	if i := s1.Intersect(s1); len(i) != 2 {
+		t.Fatal("unexpected self intersection:", i)
+	}
+
+	if i := s1.Intersect(NewSet()); len(i) != 0 {
+		t.Fatal("unexpected intersection with empty set:", i)
+	}
+}
+
+func TestSet_Difference(t *testing.T) {
+	a, b, c := must(t, New), must(t, New), must(t, New)
+	s1, s2 := NewSet(a, b), NewSet(b, c)
+
+	if d := s1.Difference(s2); len(d) != 1 || !d.Has(a) {
+		t.Fatal("unexpected difference:", d)
+	}
+
+	if d := s1.Difference(s1); len(d) != 0 {
+		t.Fatal("unexpected self difference:", d)
+	}
+
+	if d := s1.Difference(nil); len(d) != 2 {
+		t.Fatal("unexpected difference with empty set:", d)
+	}
+
+	if d := Set(nil).Difference(s1); len(d) != 0 {
+		t.Fatal("unexpected difference of empty set:", d)
+	}
+}
+
+func TestSet_Slice(t *testing.T) {
+	s := NewSet()
+	for i := 0; i < 100; i++ {
+		s.Add(must(t, New))
+	}
+
+	ids := s.Slice()
+	if len(ids) != 100 {
+		t.Fatal("unexpected length:", len(ids))
+	}
+
+	sorted := sort.SliceIsSorted(ids, func(i, j int) bool { return bytes.Compare(ids[i][:], ids[j][:]) < 0 })
+	if !sorted {
+		t.Fatal("slice is not sorted")
+	}
+
+	for _, id := range ids {
+		if !s.Has(id) {
+			t.Fatal("unexpected uuid:", id)
+		}
+	}
+}
+
+func TestMerge(t *testing.T) {
+	table := []struct {
+		name string
+		a    []UUID
+		b    []UUID
+		want []UUID
+	}{
+		{"empty", nil, nil, []UUID{}},
+		{"empty a", nil, []UUID{FromInt(1), FromInt(2)}, []UUID{FromInt(1), FromInt(2)}},
+		{"empty b", []UUID{FromInt(1), FromInt(2)}, nil, []UUID{FromInt(1), FromInt(2)}},
+		{"disjoint", []UUID{FromInt(1), FromInt(2)}, []UUID{FromInt(3), FromInt(4)}, []UUID{FromInt(1), FromInt(2), FromInt(3), FromInt(4)}},
+		{"interleaved", []UUID{FromInt(1), FromInt(3)}, []UUID{FromInt(2), FromInt(4)}, []UUID{FromInt(1), FromInt(2), FromInt(3), FromInt(4)}},
+		{"overlapping", []UUID{FromInt(1), FromInt(2), FromInt(3)}, []UUID{FromInt(2), FromInt(3), FromInt(4)}, []UUID{FromInt(1), FromInt(2), FromInt(3), FromInt(4)}},
+		{"duplicates", []UUID{FromInt(1), FromInt(1)}, []UUID{FromInt(1)}, []UUID{FromInt(1)}},
+	}
+
+	for _, tt := range table {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			got := Merge(tt.a, tt.b)
+			if len(got) != len(tt.want) {
+				t.Fatalf("unexpected merged slice: %v", got)
+			}
+
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Fatalf("unexpected merged slice: %v", got)
+				}
+			}
+		})
+	}
+}
+
+func TestMerge_Random(t *testing.T) {
+	a, b := NewSet(), NewSet()
+	for i := 0; i < 100; i++ {
+		id := must(t, New)
+		a.Add(id)
+		if i%2 == 0 {
+			b.Add(id)
+		}
+		b.Add(must(t, New))
+	}
+
+	merged := Merge(a.Slice(), b.Slice())
+	want := a.Union(b).Slice()
+	if len(merged) != len(want) {
+		t.Fatal("unexpected length:", len(merged))
+	}
+
+	for i := range merged {
+		if merged[i] != want[i] {
+			t.Fatal("unexpected uuid:", merged[i])
+		}
+	}
+}
+
+func TestUUIDs_Sort(t *testing.T) {
+	v7 := NewV7Generator(SecureReader)
+	created := make(UUIDs, 100)
+	for i := range created {
+		created[i] = must(t, v7.NewUUID)
+	}
+
+	ids := append(UUIDs(nil), created...)
+	rand.New(rand.NewSource(1)).Shuffle(len(ids), ids.Swap)
+	ids.Sort()
+	if !sort.IsSorted(ids) {
+		t.Fatal("slice is not sorted")
+	}
+
+	for i := range ids {
+		if ids[i] != created[i] {
+			t.Fatalf("unexpected uuid at %d: %s, want %s", i, ids[i], created[i])
+		}
+	}
+}
+
+func TestUUIDs_Strings(t *testing.T) {
+	ids := UUIDs{MustParse(StaticUUID), Nil}
+	got := ids.Strings()
+	if len(got) != 2 || got[0] != StaticUUID || got[1] != Nil.String() {
+		t.Fatal("unexpected strings:", got)
+	}
+
+	if got := UUIDs(nil).Strings(); len(got) != 0 {
+		t.Fatal("unexpected strings:", got)
+	}
+}
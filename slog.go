@@ -0,0 +1,11 @@
+//go:build go1.21
+
+package uuid
+
+import "log/slog"
+
+// LogValue implements slog.LogValuer, it logs the uuid as its canonical
+// string instead of an array of 16 bytes.
+func (id UUID) LogValue() slog.Value {
+	return slog.StringValue(id.String())
+}
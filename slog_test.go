@@ -0,0 +1,39 @@
+//go:build go1.21
+
+package uuid
+
+import (
+	"bytes"
+	"log/slog"
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestUUID_LogValue(t *testing.T) {
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewJSONHandler(&buf, nil))
+
+	// nested in a group, the uuid is still logged as a string.
+	uid := MustParse(StaticUUID)
+	logger.Info("created", "id", uid, "order", slog.GroupValue(slog.Any("id", uid)))
+	if want := `"id":"` + StaticUUID + `","order":{"id":"` + StaticUUID + `"}`; !strings.Contains(buf.String(), want) {
+		t.Fatal("unexpected log:", buf.String())
+	}
+}
+
+func ExampleUUID_LogValue() {
+	// drops the time to have a stable output.
+	logger := slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{
+		ReplaceAttr: func(groups []string, a slog.Attr) slog.Attr {
+			if a.Key == slog.TimeKey && len(groups) == 0 {
+				return slog.Attr{}
+			}
+			return a
+		},
+	}))
+
+	uid := MustParse("6ba7b810-9dad-11d1-80b4-00c04fd430c8")
+	logger.Info("created", "id", uid)
+	// Output: level=INFO msg=created id=6ba7b810-9dad-11d1-80b4-00c04fd430c8
+}
@@ -0,0 +1,111 @@
+package uuid
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+)
+
+// Scan implements sql.Scanner, it accepts the canonical string of a UUID as
+// a string or []byte, the 16 raw bytes of a binary column, or NULL which
+// sets the uuid to Nil. A []byte is read as raw bytes when it's 16 bytes
+// long, and as text otherwise, so a truncated string is never mistaken for
+// a binary UUID.
+func (id *UUID) Scan(src interface{}) error {
+	var (
+		uid UUID
+		err error
+	)
+
+	// the errors of Parse already have the uuid: prefix.
+	switch v := src.(type) {
+	case nil:
+	case string:
+		if uid, err = Parse(v); err != nil {
+			return fmt.Errorf("%w, cannot scan %q into UUID", err, v)
+		}
+	case []byte:
+		if len(v) == len(uid) {
+			uid, err = FromBytes(v)
+		} else {
+			uid, err = Parse(string(v))
+		}
+
+		// the bytes may be binary, only their length is reported.
+		if err != nil {
+			return fmt.Errorf("%w, cannot scan %d bytes into UUID", err, len(v))
+		}
+	default:
+		return fmt.Errorf("uuid: cannot scan type %T into UUID", src)
+	}
+
+	*id = uid
+	return nil
+}
+
+// Value implements driver.Valuer, it returns the canonical string of the
+// uuid.
+func (id UUID) Value() (driver.Value, error) {
+	return id.String(), nil
+}
+
+// NullUUID represents a UUID that may be null, as sql.NullString does for a
+// string. It can be scanned from a nullable column and encoded as a JSON
+// null, so Nil is not needed as an in-band sentinel.
+type NullUUID struct {
+	UUID  UUID
+	Valid bool // Valid is true if UUID is not NULL
+}
+
+// Scan implements sql.Scanner, NULL sets Valid to false and any other value
+// is scanned as UUID.Scan does. As for sql.NullString, a failed scan resets
+// the UUID to Nil and Valid to false.
+func (n *NullUUID) Scan(src interface{}) error {
+	if src == nil {
+		n.UUID, n.Valid = Nil, false
+		return nil
+	}
+
+	if err := n.UUID.Scan(src); err != nil {
+		n.UUID, n.Valid = Nil, false
+		return err
+	}
+
+	n.Valid = true
+	return nil
+}
+
+// Value implements driver.Valuer, it returns nil when Valid is false and the
+// canonical string of the UUID otherwise.
+func (n NullUUID) Value() (driver.Value, error) {
+	if !n.Valid {
+		return nil, nil
+	}
+	return n.UUID.Value()
+}
+
+// MarshalJSON implements json.Marshaler, it returns null when Valid is false
+// and the canonical string of the UUID otherwise.
+func (n NullUUID) MarshalJSON() ([]byte, error) {
+	if !n.Valid {
+		return []byte("null"), nil
+	}
+	return json.Marshal(n.UUID)
+}
+
+// UnmarshalJSON implements json.Unmarshaler, null sets Valid to false and a
+// string is parsed as the canonical string of the UUID.
+func (n *NullUUID) UnmarshalJSON(b []byte) error {
+	if bytes.Equal(b, []byte("null")) {
+		n.UUID, n.Valid = Nil, false
+		return nil
+	}
+
+	if err := json.Unmarshal(b, &n.UUID); err != nil {
+		return err
+	}
+
+	n.Valid = true
+	return nil
+}
@@ -0,0 +1,259 @@
+package uuid
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"io"
+	"testing"
+)
+
+// fakeDriver is a database/sql driver whose queries return a single column
+// with the values of fakeRows, and whose statements record their arguments
+// in fakeArgs.
+type fakeDriver struct{}
+
+var (
+	fakeRows []driver.Value
+	fakeArgs []driver.Value
+)
+
+func init() {
+	sql.Register("uuid-fake", fakeDriver{})
+}
+
+func (fakeDriver) Open(string) (driver.Conn, error) { return fakeConn{}, nil }
+
+type fakeConn struct{}
+
+func (fakeConn) Prepare(string) (driver.Stmt, error) { return fakeStmt{}, nil }
+func (fakeConn) Close() error                        { return nil }
+func (fakeConn) Begin() (driver.Tx, error)           { return nil, errors.New("not supported") }
+
+type fakeStmt struct{}
+
+func (fakeStmt) Close() error  { return nil }
+func (fakeStmt) NumInput() int { return -1 }
+
+func (fakeStmt) Exec(args []driver.Value) (driver.Result, error) {
+	fakeArgs = args
+	return driver.RowsAffected(1), nil
+}
+
+func (fakeStmt) Query([]driver.Value) (driver.Rows, error) {
+	return &fakeDriverRows{values: fakeRows}, nil
+}
+
+type fakeDriverRows struct {
+	values []driver.Value
+}
+
+func (r *fakeDriverRows) Columns() []string { return []string{"id"} }
+func (r *fakeDriverRows) Close() error      { return nil }
+
+func (r *fakeDriverRows) Next(dest []driver.Value) error {
+	if len(r.values) == 0 {
+		return io.EOF
+	}
+
+	dest[0], r.values = r.values[0], r.values[1:]
+	return nil
+}
+
+// openFake opens a database using the fake driver, returning the rows.
+func openFake(t *testing.T, rows ...driver.Value) *sql.DB {
+	t.Cleanup(func() { fakeRows, fakeArgs = nil, nil })
+	fakeRows = rows
+
+	db, err := sql.Open("uuid-fake", "")
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	t.Cleanup(func() { db.Close() })
+	return db
+}
+
+func TestUUID_Scan(t *testing.T) {
+	uid := must(t, func() (UUID, error) { return Parse(StaticUUID) })
+	db := openFake(t, StaticUUID, []byte(StaticUUID), uid[:], nil)
+
+	q, err := db.Query("SELECT id FROM t")
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	defer q.Close()
+
+	want := []UUID{uid, uid, uid, Nil}
+	for i := 0; q.Next(); i++ {
+		got := Max
+		if err := q.Scan(&got); err != nil {
+			t.Fatal("unexpected error:", err)
+		}
+
+		if got != want[i] {
+			t.Fatalf("unexpected uuid at row %d: %s", i, got)
+		}
+	}
+
+	if err := q.Err(); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+}
+
+func TestUUID_Scan_Errors(t *testing.T) {
+	table := []struct {
+		name string
+		src  interface{}
+	}{
+		{"truncated string", StaticUUID[:16]},
+		{"truncated bytes", []byte(StaticUUID[:17])},
+		{"invalid chars", "12345678-1234-1234-1234-12345678901g"},
+		{"empty", ""},
+		{"unsupported type", int64(42)},
+	}
+
+	for _, tt := range table {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			uid := Max
+			if err := uid.Scan(tt.src); err == nil {
+				t.Fatal("expected error, got nil")
+			}
+
+			if uid != Max {
+				t.Fatal("unexpected modified uuid:", uid)
+			}
+		})
+	}
+
+	// a truncated string of 16 characters is not read as raw bytes.
+	var uid UUID
+	err := uid.Scan(StaticUUID[:16])
+	if !errors.Is(err, ErrLength) {
+		t.Fatal("unexpected error:", err)
+	}
+
+	if want := `uuid: incorrect UUID length, cannot scan "00010203-0405-46" into UUID`; err.Error() != want {
+		t.Fatal("unexpected error:", err)
+	}
+
+	// the content of the bytes is not reported, they may be binary.
+	err = uid.Scan([]byte{0x00, 0x01, 0xff})
+	if !errors.Is(err, ErrLength) {
+		t.Fatal("unexpected error:", err)
+	}
+
+	if want := "uuid: incorrect UUID length, cannot scan 3 bytes into UUID"; err.Error() != want {
+		t.Fatal("unexpected error:", err)
+	}
+}
+
+func TestUUID_Value(t *testing.T) {
+	db := openFake(t)
+	uid := must(t, New)
+	if _, err := db.Exec("INSERT INTO t VALUES (?)", uid); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	if len(fakeArgs) != 1 || fakeArgs[0] != uid.String() {
+		t.Fatal("unexpected args:", fakeArgs)
+	}
+}
+
+func TestNullUUID_Scan(t *testing.T) {
+	uid := must(t, func() (UUID, error) { return Parse(StaticUUID) })
+	db := openFake(t, StaticUUID, nil, uid[:])
+
+	q, err := db.Query("SELECT id FROM t")
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	defer q.Close()
+
+	want := []NullUUID{{UUID: uid, Valid: true}, {}, {UUID: uid, Valid: true}}
+	for i := 0; q.Next(); i++ {
+		got := NullUUID{UUID: Max, Valid: true}
+		if err := q.Scan(&got); err != nil {
+			t.Fatal("unexpected error:", err)
+		}
+
+		if got != want[i] {
+			t.Fatalf("unexpected null uuid at row %d: %+v", i, got)
+		}
+	}
+
+	var n NullUUID
+	if err := n.Scan("not-a-uuid"); err == nil || n.Valid {
+		t.Fatal("expected error, got nil")
+	}
+}
+
+func TestNullUUID_Scan_Reset(t *testing.T) {
+	var n NullUUID
+	if err := n.Scan(StaticUUID); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	if !n.Valid || n.UUID.String() != StaticUUID {
+		t.Fatalf("unexpected null uuid: %+v", n)
+	}
+
+	// a failed scan doesn't keep the previous value.
+	if err := n.Scan("not-a-uuid"); err == nil {
+		t.Fatal("expected error, got nil")
+	}
+
+	if n != (NullUUID{}) {
+		t.Fatalf("unexpected null uuid: %+v", n)
+	}
+}
+
+func TestNullUUID_Value(t *testing.T) {
+	db := openFake(t)
+	uid := must(t, New)
+	if _, err := db.Exec("INSERT INTO t VALUES (?, ?)", NullUUID{UUID: uid, Valid: true}, NullUUID{UUID: uid}); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	if len(fakeArgs) != 2 || fakeArgs[0] != uid.String() || fakeArgs[1] != nil {
+		t.Fatal("unexpected args:", fakeArgs)
+	}
+}
+
+func TestNullUUID_MarshalJSON(t *testing.T) {
+	uid := must(t, func() (UUID, error) { return Parse(StaticUUID) })
+	b, err := json.Marshal([]NullUUID{{UUID: uid, Valid: true}, {UUID: uid}})
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	if want := `["` + StaticUUID + `",null]`; string(b) != want {
+		t.Fatal("unexpected json:", string(b))
+	}
+}
+
+func TestNullUUID_UnmarshalJSON(t *testing.T) {
+	uid := must(t, func() (UUID, error) { return Parse(StaticUUID) })
+
+	var got []NullUUID
+	if err := json.Unmarshal([]byte(`["`+StaticUUID+`",null]`), &got); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	if len(got) != 2 || got[0] != (NullUUID{UUID: uid, Valid: true}) || got[1] != (NullUUID{}) {
+		t.Fatal("unexpected null uuids:", got)
+	}
+
+	n := NullUUID{UUID: uid, Valid: true}
+	if err := json.Unmarshal([]byte("null"), &n); err != nil || n != (NullUUID{}) {
+		t.Fatal("unexpected null uuid:", n, err)
+	}
+
+	for _, in := range []string{`"not-a-uuid"`, `42`} {
+		var n NullUUID
+		if err := json.Unmarshal([]byte(in), &n); err == nil || n.Valid {
+			t.Fatalf("expected error for %s, got nil", in)
+		}
+	}
+}
@@ -0,0 +1,158 @@
+package uuid
+
+import (
+	"encoding/binary"
+	"fmt"
+	"time"
+)
+
+// timeNow returns the current time, it's replaced in tests to pin the clock.
+var timeNow = time.Now
+
+// gregorianOffset is the number of 100-nanosecond intervals between the
+// start of the Gregorian calendar (1582-10-15) and the Unix epoch.
+const gregorianOffset = 0x01b21dd213814000
+
+// minPlausibleTime is the earliest timestamp considered plausible, time-based
+// UUIDs predating 1990 are almost certainly forged or corrupted.
+var minPlausibleTime = time.Date(1990, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+// timestamp returns the creation time embedded in a time-based UUID: the
+// 100-nanosecond Gregorian timestamp of v1 and v6, or the Unix millisecond
+// timestamp of v7. It returns false for the other versions or variants.
+func timestamp(id UUID) (time.Time, bool) {
+	if id.Variant() != VariantRFC4122 {
+		return time.Time{}, false
+	}
+
+	switch id.Version() {
+	case 1:
+		return gregorianTime(v1Ticks(id)), true
+	case 6:
+		return gregorianTime(v6Ticks(id)), true
+	case 7:
+		return time.UnixMilli(int64(unixMillis(id))), true
+	default:
+		return time.Time{}, false
+	}
+}
+
+// Time returns the creation time embedded in a time-based UUID: the
+// 100-nanosecond Gregorian timestamp of v1 and v6, or the Unix millisecond
+// timestamp of v7. An error is returned for the other versions, such as v4,
+// and for the other variants, since they carry no timestamp.
+func (id UUID) Time() (time.Time, error) {
+	ts, ok := timestamp(id)
+	if !ok {
+		return time.Time{}, fmt.Errorf("uuid: no timestamp in version %d, %s variant UUID", id.Version(), id.Variant())
+	}
+
+	return ts, nil
+}
+
+// gregorianTime converts a count of 100-nanosecond intervals since the start
+// of the Gregorian calendar to time.
+func gregorianTime(ts uint64) time.Time {
+	unix := int64(ts) - gregorianOffset
+	return time.Unix(unix/1e7, unix%1e7*100)
+}
+
+// gregorianTimestamp converts time to a count of 100-nanosecond intervals
+// since the start of the Gregorian calendar, truncated to 60 bits.
+func gregorianTimestamp(t time.Time) uint64 {
+	return uint64(t.UnixNano()/100+gregorianOffset) & 0x0fffffffffffffff
+}
+
+// TimestampPlausible reports whether the timestamp embedded in a time-based
+// UUID (v1, v6 or v7) is not more than maxSkew ahead of now and not before
+// 1990. A timestamp far in the future indicates a replayed or forged UUID.
+// UUIDs without a timestamp have nothing to check, so it returns true.
+func (id UUID) TimestampPlausible(maxSkew time.Duration) bool {
+	ts, ok := timestamp(id)
+	if !ok {
+		return true
+	}
+
+	return !ts.After(timeNow().Add(maxSkew)) && !ts.Before(minPlausibleTime)
+}
+
+// unixMillis returns the 48-bit big-endian Unix millisecond timestamp stored
+// in the first 6 bytes of a UUID v7.
+func unixMillis(id UUID) uint64 {
+	return uint64(id[0])<<40 | uint64(id[1])<<32 | uint64(binary.BigEndian.Uint32(id[2:6]))
+}
+
+// putUnixMillis stores the 48-bit big-endian Unix millisecond timestamp ms
+// in the first 6 bytes of a UUID v7.
+func putUnixMillis(id *UUID, ms uint64) {
+	id[0] = byte(ms >> 40)
+	id[1] = byte(ms >> 32)
+	binary.BigEndian.PutUint32(id[2:6], uint32(ms))
+}
+
+// QuantizeTime returns a copy of a UUID v7 with its timestamp truncated to a
+// multiple of resolution, e.g. time.Hour, keeping the random tail and the
+// version and variant bits. Resolutions below a millisecond, including zero
+// and the negative ones, leave the timestamp unchanged. It returns false if
+// the uuid is not v7.
+//
+// The coarsened UUIDs reveal less about their creation time and stay ordered
+// across buckets, but not within a bucket: the UUIDs of the same bucket share
+// the same timestamp and are ordered by their random tails. Keeping their
+// order would require encoding the finer time in the tail, which is exactly
+// what the quantization hides.
+func (id UUID) QuantizeTime(resolution time.Duration) (UUID, bool) {
+	if id.Version() != 7 || id.Variant() != VariantRFC4122 {
+		return Nil, false
+	}
+
+	// the negative resolutions would wrap once converted to uint64.
+	if resolution < time.Millisecond {
+		return id, true
+	}
+
+	res := uint64(resolution.Milliseconds())
+	ms := unixMillis(id)
+	putUnixMillis(&id, ms-ms%res)
+	return id, true
+}
+
+// ChronoCompare compares a and b by their best-effort generation order,
+// returning -1, 0 or +1. When both carry a timestamp (v1, v6 or v7) they're
+// compared by time. A time-based UUID always sorts before one without a
+// timestamp, such as v4. Ties and UUIDs without timestamps are compared by
+// their bytes, so it's a total order usable while migrating from random to
+// time-ordered keys.
+func ChronoCompare(a, b UUID) int {
+	ta, oka := timestamp(a)
+	tb, okb := timestamp(b)
+	switch {
+	case oka && !okb:
+		return -1
+	case !oka && okb:
+		return 1
+	case oka && okb && ta.Before(tb):
+		return -1
+	case oka && okb && ta.After(tb):
+		return 1
+	default:
+		return a.Compare(b)
+	}
+}
+
+// WithinOf reports whether the timestamps of id and other, both time-based
+// UUIDs (v1, v6 or v7), differ by at most d. The second result is false, as
+// is the first one, when either uuid carries no timestamp.
+func (id UUID) WithinOf(other UUID, d time.Duration) (bool, bool) {
+	ta, oka := timestamp(id)
+	tb, okb := timestamp(other)
+	if !oka || !okb {
+		return false, false
+	}
+
+	diff := ta.Sub(tb)
+	if diff < 0 {
+		diff = -diff
+	}
+	return diff <= d, true
+}
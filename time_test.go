@@ -0,0 +1,280 @@
+package uuid
+
+import (
+	"bytes"
+	"testing"
+	"time"
+)
+
+// pinClock replaces timeNow with a clock stuck at now until the test ends.
+func pinClock(t *testing.T, now time.Time) {
+	t.Cleanup(func() { timeNow = time.Now })
+	timeNow = func() time.Time { return now }
+}
+
+// v7At returns a version 7 UUID with the given timestamp and a zero tail.
+func v7At(ts time.Time) UUID {
+	var uid UUID
+	putUnixMillis(&uid, uint64(ts.UnixMilli()))
+	uid[6] = 0x70
+	uid[8] = 0x80
+	return uid
+}
+
+func TestUUID_TimestampPlausible(t *testing.T) {
+	now := time.Date(2024, time.May, 1, 12, 0, 0, 0, time.UTC)
+	pinClock(t, now)
+
+	table := []struct {
+		name string
+		uid  UUID
+		want bool
+	}{
+		{"now", v7At(now), true},
+		{"within skew", v7At(now.Add(30 * time.Second)), true},
+		{"past", v7At(now.Add(-24 * time.Hour)), true},
+		{"future", v7At(now.Add(time.Hour)), false},
+		{"absurd past", v7At(time.Unix(0, 0)), false},
+		{"v1 now", must(t, func() (UUID, error) { return Parse("55fd2000-07b2-11ef-8000-000000000000") }), true},
+		{"v6 now", must(t, func() (UUID, error) { return Parse("1ef07b25-5fd2-6000-8000-000000000000") }), true},
+		{"v1 future", must(t, func() (UUID, error) { return Parse("55fd2000-07b2-12ef-8000-000000000000") }), false},
+		{"v4", must(t, New), true},
+		{"nil", Nil, true},
+	}
+
+	for _, tt := range table {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.uid.TimestampPlausible(time.Minute); got != tt.want {
+				t.Fatalf("unexpected result: %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTimestamp(t *testing.T) {
+	want := time.Date(2024, time.May, 1, 12, 0, 0, 0, time.UTC)
+	for _, s := range []string{"55fd2000-07b2-11ef-8000-000000000000", "1ef07b25-5fd2-6000-8000-000000000000"} {
+		uid := must(t, func() (UUID, error) { return Parse(s) })
+		ts, ok := timestamp(uid)
+		if !ok || !ts.Equal(want) {
+			t.Fatal("unexpected timestamp:", ts, ok)
+		}
+	}
+
+	ts, ok := timestamp(v7At(want))
+	if !ok || !ts.Equal(want) {
+		t.Fatal("unexpected timestamp:", ts, ok)
+	}
+}
+
+func TestUUID_Time(t *testing.T) {
+	now := time.Date(2024, time.May, 1, 12, 0, 0, 123456700, time.UTC)
+	pinClock(t, now)
+
+	v1, err := NewV1Generator(nil, SecureReader)
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	v6, err := NewV6Generator(nil, SecureReader)
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	v7 := NewV7GeneratorWithClock(SecureReader, timeNow)
+	table := []struct {
+		name string
+		g    Generator
+		want time.Time
+	}{
+		{"v1", v1, now},
+		{"v6", v6, now},
+		{"v7", v7, now.Truncate(time.Millisecond)},
+	}
+
+	for _, tt := range table {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			ts, err := must(t, tt.g.NewUUID).Time()
+			if err != nil {
+				t.Fatal("unexpected error:", err)
+			}
+
+			if !ts.Equal(tt.want) {
+				t.Fatal("unexpected time:", ts)
+			}
+		})
+	}
+}
+
+func TestUUID_Time_Errors(t *testing.T) {
+	table := []struct {
+		name string
+		in   UUID
+	}{
+		{"v4", MustParse(StaticUUID)},
+		{"v5", NewV5(NamespaceDNS, []byte("www.example.com"))},
+		{"nil", Nil},
+		{"max", Max},
+		{"v7 microsoft variant", MustParse("018f3b6e-27c0-7000-c000-000000000000")},
+	}
+
+	for _, tt := range table {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			ts, err := tt.in.Time()
+			if err == nil {
+				t.Fatal("expected error, got nil")
+			}
+
+			if !ts.IsZero() {
+				t.Fatal("unexpected time:", ts)
+			}
+		})
+	}
+}
+
+func TestUUID_QuantizeTime(t *testing.T) {
+	ts := time.Date(2024, time.May, 1, 12, 34, 56, 789e6, time.UTC)
+	uid := v7At(ts)
+	random := must(t, New)
+	copy(uid[9:], random[9:])
+	uid[7] = 0xab
+
+	q, ok := uid.QuantizeTime(time.Hour)
+	if !ok {
+		t.Fatal("expected a v7 uuid:", uid)
+	}
+
+	if got, _ := timestamp(q); !got.Equal(ts.Truncate(time.Hour)) {
+		t.Fatal("unexpected quantized time:", got)
+	}
+
+	if !bytes.Equal(q[6:], uid[6:]) {
+		t.Fatal("unexpected tail:", q)
+	}
+
+	for _, res := range []time.Duration{time.Millisecond, time.Microsecond, 0, -time.Millisecond, -time.Hour} {
+		same, ok := uid.QuantizeTime(res)
+		if !ok || same != uid {
+			t.Fatalf("unexpected quantized uuid with resolution %s: %s", res, same)
+		}
+	}
+
+	if _, ok := must(t, New).QuantizeTime(time.Hour); ok {
+		t.Fatal("unexpected quantized v4 uuid")
+	}
+}
+
+func TestUUID_QuantizeTime_Order(t *testing.T) {
+	ts := time.Date(2024, time.May, 1, 12, 0, 0, 0, time.UTC)
+
+	// 3 hours of uuids, one every minute, sorted by time.
+	uids := make([]UUID, 180)
+	for i := range uids {
+		uids[i] = v7At(ts.Add(time.Duration(i) * time.Minute))
+		random := must(t, New)
+		copy(uids[i][9:], random[9:])
+	}
+
+	for i := range uids {
+		for j := i + 1; j < len(uids); j++ {
+			qi, _ := uids[i].QuantizeTime(time.Hour)
+			qj, _ := uids[j].QuantizeTime(time.Hour)
+
+			// uuids of different buckets keep their order.
+			if i/60 != j/60 {
+				if qi.Compare(qj) != -1 {
+					t.Fatalf("unexpected order of %s and %s", qi, qj)
+				}
+				continue
+			}
+
+			// the ones of the same bucket carry no finer time to order them.
+			if !bytes.Equal(qi[:6], qj[:6]) {
+				t.Fatalf("unexpected timestamps of %s and %s", qi, qj)
+			}
+		}
+	}
+}
+
+func TestChronoCompare(t *testing.T) {
+	now := time.Date(2024, time.May, 1, 12, 0, 0, 0, time.UTC)
+	v1 := must(t, func() (UUID, error) { return Parse("55fd2000-07b2-11ef-8000-000000000000") })
+	v6 := must(t, func() (UUID, error) { return Parse("1ef07b25-5fd2-6000-8000-000000000000") })
+	a := FromInt(1)
+	a[6], a[8] = 0x40, 0x80
+	b := FromInt(2)
+	b[6], b[8] = 0x40, 0x80
+
+	table := []struct {
+		name string
+		a    UUID
+		b    UUID
+		want int
+	}{
+		{"v7 before v7", v7At(now), v7At(now.Add(time.Millisecond)), -1},
+		{"v7 after v7", v7At(now.Add(time.Millisecond)), v7At(now), 1},
+		{"v7 equal", v7At(now), v7At(now), 0},
+		{"v1 before v7", v1, v7At(now.Add(time.Millisecond)), -1},
+		{"v7 after v6", v7At(now.Add(time.Millisecond)), v6, 1},
+		{"v1 and v6 same time by bytes", v1, v6, 1},
+		{"v7 before v4", v7At(now), b, -1},
+		{"v4 after v1", a, v1, 1},
+		{"v4 by bytes", a, b, -1},
+		{"v4 by bytes reversed", b, a, 1},
+		{"v4 equal", a, a, 0},
+		{"nil before v4", Nil, a, -1},
+	}
+
+	for _, tt := range table {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ChronoCompare(tt.a, tt.b); got != tt.want {
+				t.Fatalf("unexpected result: %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestUUID_WithinOf(t *testing.T) {
+	now := time.Date(2024, time.May, 1, 12, 0, 0, 0, time.UTC)
+	v7 := NewV7GeneratorWithClock(SecureReader, func() time.Time { return now })
+
+	a := must(t, v7.NewUUID)
+	now = now.Add(40 * time.Millisecond)
+	b := must(t, v7.NewUUID)
+	now = now.Add(time.Hour)
+	c := must(t, v7.NewUUID)
+
+	v1 := must(t, func() (UUID, error) { return Parse("55fd2000-07b2-11ef-8000-000000000000") })
+	table := []struct {
+		name   string
+		a      UUID
+		b      UUID
+		d      time.Duration
+		within bool
+		ok     bool
+	}{
+		{"same", a, a, 0, true, true},
+		{"close", a, b, 50 * time.Millisecond, true, true},
+		{"close reversed", b, a, 50 * time.Millisecond, true, true},
+		{"exact bound", a, b, 40 * time.Millisecond, true, true},
+		{"too far", a, b, 39 * time.Millisecond, false, true},
+		{"far apart", a, c, time.Minute, false, true},
+		{"mixed versions", a, v1, 0, true, true},
+		{"no timestamp", a, must(t, New), time.Hour, false, false},
+		{"nil", Nil, Nil, time.Hour, false, false},
+	}
+
+	for _, tt := range table {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			within, ok := tt.a.WithinOf(tt.b, tt.d)
+			if within != tt.within || ok != tt.ok {
+				t.Fatalf("unexpected result: %v, %v", within, ok)
+			}
+		})
+	}
+}
@@ -0,0 +1,95 @@
+package uuid
+
+import (
+	"bytes"
+	"crypto/aes"
+)
+
+// Reverse returns a copy of the uuid with all 16 bytes in reverse order, as
+// stored by systems that keep UUIDs fully byte-reversed. Unlike the field-wise
+// GUID layout, the whole array is reversed, so applying Reverse twice yields
+// the original uuid.
+func (id UUID) Reverse() UUID {
+	var rev UUID
+	for i, b := range id {
+		rev[len(id)-1-i] = b
+	}
+	return rev
+}
+
+// PayloadEqual reports whether id and other are equal once the 4 version
+// bits (the high nibble of byte 6) and the 2 variant bits (the top bits of
+// byte 8) are masked out. It's useful to detect that two UUIDs of different
+// versions share the same underlying payload.
+func (id UUID) PayloadEqual(other UUID) bool {
+	id[6], other[6] = id[6]&0x0f, other[6]&0x0f
+	id[8], other[8] = id[8]&0x3f, other[8]&0x3f
+	return id == other
+}
+
+// XORDistance returns the bytewise XOR of id and other, which is the
+// Kademlia distance between them when interpreted as a 128-bit big-endian
+// integer. The distance of an uuid to itself is Nil.
+func (id UUID) XORDistance(other UUID) UUID {
+	var d UUID
+	for i := range d {
+		d[i] = id[i] ^ other[i]
+	}
+	return d
+}
+
+// CloserTo compares the XOR distances of a and b to the uuid. It returns -1
+// if a is closer, +1 if b is closer, and 0 if both are at the same distance,
+// which only happens when a and b are equal.
+func (id UUID) CloserTo(a, b UUID) int {
+	da, db := id.XORDistance(a), id.XORDistance(b)
+	return bytes.Compare(da[:], db[:])
+}
+
+// GrayEncode returns the reflected binary Gray code of the uuid interpreted
+// as a 128-bit big-endian integer, that is x ^ (x >> 1). The Gray codes of
+// two consecutive integers differ by a single bit.
+func (id UUID) GrayEncode() UUID {
+	hi, lo := toUint128(id)
+	return fromUint128(hi^hi>>1, lo^(lo>>1|hi<<63))
+}
+
+// GrayDecode returns the 128-bit integer whose Gray code is the uuid, it's
+// the inverse of GrayEncode.
+func (id UUID) GrayDecode() UUID {
+	hi, lo := toUint128(id)
+	for shift := uint(1); shift < 128; shift <<= 1 {
+		// (hi, lo) ^= (hi, lo) >> shift
+		if shift < 64 {
+			lo ^= lo>>shift | hi<<(64-shift)
+			hi ^= hi >> shift
+		} else {
+			lo ^= hi >> (shift - 64)
+		}
+	}
+	return fromUint128(hi, lo)
+}
+
+// Pseudonymize returns the uuid encrypted with AES-128 under the given key,
+// as a single 16-byte block. It's a keyed bijection: the same key always maps
+// an uuid to the same pseudonym, two uuids never share one, and the mapping
+// is opaque without the key. The version and variant bits are not kept.
+// Use Depseudonymize with the same key to recover the original uuid.
+func (id UUID) Pseudonymize(key [16]byte) UUID {
+	// a 16-byte key is always a valid AES key.
+	block, _ := aes.NewCipher(key[:])
+
+	var out UUID
+	block.Encrypt(out[:], id[:])
+	return out
+}
+
+// Depseudonymize returns the original uuid of a pseudonym returned by
+// Pseudonymize with the same key.
+func (id UUID) Depseudonymize(key [16]byte) UUID {
+	block, _ := aes.NewCipher(key[:])
+
+	var out UUID
+	block.Decrypt(out[:], id[:])
+	return out
+}
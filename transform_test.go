@@ -0,0 +1,200 @@
+package uuid
+
+import (
+	"math/bits"
+	"testing"
+)
+
+func TestUUID_Reverse(t *testing.T) {
+	uid := must(t, func() (UUID, error) { return Parse(StaticUUID) })
+	rev := uid.Reverse()
+	if rev.String() != "0f0e0d0c-0b0a-0988-0746-050403020100" {
+		t.Fatal("unexpected uuid:", rev)
+	}
+
+	if rev.Reverse() != uid {
+		t.Fatal("unexpected uuid:", rev.Reverse())
+	}
+
+	// takes 100 random samples.
+	for i := 0; i < 100; i++ {
+		uid = must(t, New)
+		if uid.Reverse().Reverse() != uid {
+			t.Fatal("unexpected uuid:", uid.Reverse().Reverse())
+		}
+	}
+}
+
+func TestUUID_PayloadEqual(t *testing.T) {
+	uid := must(t, func() (UUID, error) { return Parse(StaticUUID) })
+
+	// same payload with version 8 and the variant bits cleared.
+	other := uid
+	other[6] = (other[6] & 0x0f) | 0x80
+	other[8] &= 0x3f
+	if !uid.PayloadEqual(other) {
+		t.Fatal("expected equal payload:", uid, other)
+	}
+
+	if uid == other {
+		t.Fatal("unexpected equal uuid:", uid, other)
+	}
+
+	// the low nibble of the version byte is part of the payload.
+	other = uid
+	other[6] ^= 0x01
+	if uid.PayloadEqual(other) {
+		t.Fatal("unexpected equal payload:", uid, other)
+	}
+
+	other = must(t, New)
+	if uid.PayloadEqual(other) {
+		t.Fatal("unexpected equal payload:", uid, other)
+	}
+}
+
+func TestUUID_XORDistance(t *testing.T) {
+	a := must(t, New)
+	b := must(t, New)
+
+	if d := a.XORDistance(a); d != Nil {
+		t.Fatal("unexpected distance to self:", d)
+	}
+
+	if a.XORDistance(b) != b.XORDistance(a) {
+		t.Fatal("distance is not symmetric:", a, b)
+	}
+
+	if a.XORDistance(Nil) != a {
+		t.Fatal("unexpected distance to nil:", a.XORDistance(Nil))
+	}
+
+	// d(a, b) xor d(b, c) == d(a, c).
+	c := must(t, New)
+	if a.XORDistance(b).XORDistance(b.XORDistance(c)) != a.XORDistance(c) {
+		t.Fatal("unexpected distance:", a, b, c)
+	}
+
+	uid := must(t, func() (UUID, error) { return Parse(StaticUUID) })
+	other := must(t, func() (UUID, error) { return Parse("ff010203-0405-4607-8809-0a0b0c0d0e00") })
+	if d := uid.XORDistance(other); d.String() != "ff000000-0000-0000-0000-00000000000f" {
+		t.Fatal("unexpected distance:", d)
+	}
+}
+
+func TestUUID_CloserTo(t *testing.T) {
+	id := must(t, func() (UUID, error) { return Parse(StaticUUID) })
+	near := id
+	near[15] ^= 0x01
+	far := id
+	far[0] ^= 0x01
+
+	if got := id.CloserTo(near, far); got != -1 {
+		t.Fatal("unexpected result:", got)
+	}
+
+	if got := id.CloserTo(far, near); got != 1 {
+		t.Fatal("unexpected result:", got)
+	}
+
+	if got := id.CloserTo(near, near); got != 0 {
+		t.Fatal("unexpected result:", got)
+	}
+
+	if got := id.CloserTo(id, near); got != -1 {
+		t.Fatal("unexpected result:", got)
+	}
+}
+
+func TestUUID_GrayEncode(t *testing.T) {
+	table := []struct {
+		in   UUID
+		want UUID
+	}{
+		{FromInt(0), FromInt(0)},
+		{FromInt(1), FromInt(1)},
+		{FromInt(2), FromInt(3)},
+		{FromInt(3), FromInt(2)},
+		{FromInt(4), FromInt(6)},
+		{FromInt(0xffffffffffffffff).Increment(), fromUint128(1, 1<<63)},
+	}
+
+	for _, tt := range table {
+		if got := tt.in.GrayEncode(); got != tt.want {
+			t.Fatalf("GrayEncode(%s) = %s, want %s", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestUUID_GrayDecode(t *testing.T) {
+	// takes 100 random samples.
+	for i := 0; i < 100; i++ {
+		uid := must(t, New)
+		if got := uid.GrayEncode().GrayDecode(); got != uid {
+			t.Fatal("unexpected uuid:", got)
+		}
+
+		if got := uid.GrayDecode().GrayEncode(); got != uid {
+			t.Fatal("unexpected uuid:", got)
+		}
+	}
+}
+
+func TestUUID_GrayEncode_SingleBit(t *testing.T) {
+	// includes the carry across the 64-bit halves.
+	starts := []UUID{Nil, must(t, New), FromInt(0xffffffffffffff00)}
+	for _, uid := range starts {
+		for i := 0; i < 512; i++ {
+			next := uid.Increment()
+			a, b := uid.GrayEncode(), next.GrayEncode()
+			diff := 0
+			for j := range a {
+				diff += bits.OnesCount8(a[j] ^ b[j])
+			}
+
+			if diff != 1 {
+				t.Fatalf("gray codes of %s and %s differ by %d bits", uid, next, diff)
+			}
+			uid = next
+		}
+	}
+}
+
+func TestUUID_Pseudonymize(t *testing.T) {
+	key := [16]byte{0x2b, 0x7e, 0x15, 0x16, 0x28, 0xae, 0xd2, 0xa6, 0xab, 0xf7, 0x15, 0x88, 0x09, 0xcf, 0x4f, 0x3c}
+	other := key
+	other[0] ^= 0x01
+
+	seen := make(map[UUID]bool)
+	// takes 100 random samples.
+	for i := 0; i < 100; i++ {
+		uid := must(t, New)
+		p := uid.Pseudonymize(key)
+		if p == uid || seen[p] {
+			t.Fatal("unexpected pseudonym:", p)
+		}
+		seen[p] = true
+
+		if p != uid.Pseudonymize(key) {
+			t.Fatal("pseudonym is not deterministic:", p)
+		}
+
+		if p == uid.Pseudonymize(other) {
+			t.Fatal("unexpected equal pseudonym with another key:", p)
+		}
+
+		if got := p.Depseudonymize(key); got != uid {
+			t.Fatal("unexpected uuid:", got)
+		}
+
+		if got := p.Depseudonymize(other); got == uid {
+			t.Fatal("unexpected uuid with another key:", got)
+		}
+	}
+
+	// FIPS-197 appendix B test vector.
+	in := UUID{0x32, 0x43, 0xf6, 0xa8, 0x88, 0x5a, 0x30, 0x8d, 0x31, 0x31, 0x98, 0xa2, 0xe0, 0x37, 0x07, 0x34}
+	if got := in.Pseudonymize(key); got.String() != "3925841d-02dc-09fb-dc11-8597196a0b32" {
+		t.Fatal("unexpected pseudonym:", got)
+	}
+}
@@ -4,35 +4,151 @@ import (
 	"bytes"
 	"crypto/rand"
 	"encoding/hex"
+	"errors"
 	"fmt"
 	"io"
+	mathrand "math/rand"
+	"strconv"
 	"sync"
 )
 
 // defaultGenerator is singleton of generator, it's used as default generator.
 var defaultGenerator Generator
-var defaultInitiator sync.Once
+var defaultMutex sync.RWMutex
 
-// IsV4 returns true if the given UUID is a valid UUID v4.
-func IsV4(uid UUID) bool {
+// IsVersion returns true if the given UUID is a valid UUID of version v,
+// that is with the version bits set to v and the variant bits set to 10.
+// Like for IsV4, Nil is valid for every version, use Validate to reject it.
+func IsVersion(uid UUID, v int) bool {
 	if uid == Nil {
 		return true
 	}
 
-	// check the version bits (0100 in binary, or 0x40 in hex).
-	if uid[6]>>4 != 4 {
-		return false
+	return uid.Version() == v && uid.Variant() == VariantRFC4122
+}
+
+// IsV1 returns true if the given UUID is a valid UUID v1, see IsVersion.
+func IsV1(uid UUID) bool { return IsVersion(uid, 1) }
+
+// IsV3 returns true if the given UUID is a valid UUID v3, see IsVersion.
+func IsV3(uid UUID) bool { return IsVersion(uid, 3) }
+
+// IsV4 returns true if the given UUID is a valid UUID v4, see IsVersion.
+func IsV4(uid UUID) bool { return IsVersion(uid, 4) }
+
+// IsV5 returns true if the given UUID is a valid UUID v5, see IsVersion.
+func IsV5(uid UUID) bool { return IsVersion(uid, 5) }
+
+// IsV6 returns true if the given UUID is a valid UUID v6, see IsVersion.
+func IsV6(uid UUID) bool { return IsVersion(uid, 6) }
+
+// IsV7 returns true if the given UUID is a valid UUID v7, see IsVersion.
+func IsV7(uid UUID) bool { return IsVersion(uid, 7) }
+
+// Version returns the version of the uuid, stored in the 4 high bits of
+// byte 6, e.g. 4 for a random UUID or 7 for a time-ordered one. It returns 0
+// for Nil.
+func (id UUID) Version() int {
+	return int(id[6] >> 4)
+}
+
+// Variant is the layout of a UUID, as defined in RFC 4122 section 4.1.1.
+type Variant int
+
+const (
+	// VariantNCS is reserved for the backward compatibility with the NCS.
+	VariantNCS Variant = iota
+	// VariantRFC4122 is the layout defined in RFC 4122 and RFC 9562.
+	VariantRFC4122
+	// VariantMicrosoft is reserved for the backward compatibility with the
+	// Microsoft GUIDs.
+	VariantMicrosoft
+	// VariantFuture is reserved for future definition.
+	VariantFuture
+)
+
+// String returns the name of the variant.
+func (v Variant) String() string {
+	switch v {
+	case VariantNCS:
+		return "NCS"
+	case VariantRFC4122:
+		return "RFC4122"
+	case VariantMicrosoft:
+		return "Microsoft"
+	case VariantFuture:
+		return "Future"
+	default:
+		return "unknown"
 	}
+}
 
-	// check the variant bits (1010 in binary, or 0x80 in hex).
-	if uid[8]>>6 != 2 {
-		return false
+// Variant returns the variant of the uuid, decoded from the high bits of
+// byte 8: 0xx is NCS, 10x is RFC 4122, 110 is Microsoft and 111 is Future.
+func (id UUID) Variant() Variant {
+	switch {
+	case id[8]&0x80 == 0x00:
+		return VariantNCS
+	case id[8]&0xc0 == 0x80:
+		return VariantRFC4122
+	case id[8]&0xe0 == 0xc0:
+		return VariantMicrosoft
+	default:
+		return VariantFuture
 	}
-	return true
+}
+
+// VersionIn reports whether the version of the uuid is one of the given
+// versions. It returns false for an empty list:
+//
+//	if !id.VersionIn(4, 7) {
+//		// reject
+//	}
+func (id UUID) VersionIn(versions ...int) bool {
+	v := id.Version()
+	for _, version := range versions {
+		if v == version {
+			return true
+		}
+	}
+	return false
+}
+
+// Validate returns an error unless uid is a well-formed RFC 4122 UUID, that
+// is with the variant bits set to 10, of one of the allowed versions, or of
+// any version from 1 to 8 when none is given:
+//
+//	if err := Validate(id, 4, 7); err != nil {
+//		// reject
+//	}
+//
+// Unlike IsV4, Nil is always rejected: at an API boundary it's a missing
+// value rather than an identifier.
+func Validate(uid UUID, allowed ...int) error {
+	if uid == Nil {
+		return errors.New("uuid: nil UUID")
+	}
+
+	if v := uid.Variant(); v != VariantRFC4122 {
+		return fmt.Errorf("uuid: %s variant, expected RFC4122: %s", v, uid)
+	}
+
+	v := uid.Version()
+	if len(allowed) == 0 {
+		if v < 1 || v > 8 {
+			return fmt.Errorf("uuid: unknown version %d: %s", v, uid)
+		}
+		return nil
+	}
+
+	if !uid.VersionIn(allowed...) {
+		return fmt.Errorf("uuid: version %d, expected one of %v: %s", v, allowed, uid)
+	}
+	return nil
 }
 
 func init() {
-	defaultInitiator.Do(func() { defaultGenerator = NewV4Generator(SecureReader) })
+	defaultGenerator = NewV4Generator(SecureReader)
 }
 
 // Generator knows how to generate UUID.
@@ -44,6 +160,25 @@ type Generator interface {
 // Nil is nil value of UUID.
 var Nil UUID
 
+// Max is the max value of UUID, with all bits set, as defined in RFC 9562.
+// It's usually used as an upper bound, e.g. when range-scanning an index.
+var Max = UUID{0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff}
+
+// IsMax returns true if the given UUID is the Max UUID.
+func IsMax(uid UUID) bool {
+	return uid == Max
+}
+
+// IsNil returns true if the uuid is the Nil UUID.
+func (id UUID) IsNil() bool {
+	return id == Nil
+}
+
+// IsMax returns true if the uuid is the Max UUID.
+func (id UUID) IsMax() bool {
+	return id == Max
+}
+
 // UUID is a 128 bit (16 byte) Universal Unique Identifier
 // as defined in RFC 4122.
 type UUID [16]byte
@@ -55,6 +190,28 @@ func (id UUID) String() string {
 	return string(buf[:])
 }
 
+// StringUpper returns uuid as a formatted string with uppercase hex digits,
+// e.g. 00010203-0405-4607-8809-0A0B0C0D0E0F. Parse accepts both cases.
+func (id UUID) StringUpper() string {
+	var buf [36]byte
+	encodeHex(buf[:], id)
+	for i, c := range buf {
+		// only the hex letters are above the digits and the dashes.
+		if c >= 'a' {
+			buf[i] = c - ('a' - 'A')
+		}
+	}
+	return string(buf[:])
+}
+
+// AppendString appends the formatted string of the uuid to dst and returns
+// the extended buffer, without allocating when dst has enough capacity.
+func (id UUID) AppendString(dst []byte) []byte {
+	var buf [36]byte
+	encodeHex(buf[:], id)
+	return append(dst, buf[:]...)
+}
+
 // encodeHex encodes uuid to hexadecimal string.
 func encodeHex(dst []byte, id UUID) {
 	hex.Encode(dst, id[:4])
@@ -68,8 +225,120 @@ func encodeHex(dst []byte, id UUID) {
 	hex.Encode(dst[24:], id[10:])
 }
 
-// New generates a new UUID v4 with random generator rand.Reader.
-func New() (UUID, error) { return defaultGenerator.NewUUID() }
+// New generates a new UUID with the default generator. By default, it's
+// UUID v4 with random generator rand.Reader.
+func New() (UUID, error) {
+	return DefaultGenerator().NewUUID()
+}
+
+// BatchGenerator is implemented by the generators able to generate many
+// UUIDs at once more efficiently than by calling NewUUID in a loop.
+type BatchGenerator interface {
+	Generator
+
+	// NewUUIDs creates n new UUIDs.
+	NewUUIDs(n int) ([]UUID, error)
+}
+
+// NewN generates n new UUIDs with the default generator, at once when it's a
+// BatchGenerator such as V4Generator. On any error it returns nil, never a
+// partial slice.
+func NewN(n int) ([]UUID, error) {
+	g := DefaultGenerator()
+	if bg, ok := g.(BatchGenerator); ok {
+		return bg.NewUUIDs(n)
+	}
+	return newUUIDs(g, n)
+}
+
+// newUUIDs generates n new UUIDs by calling NewUUID of g in a loop.
+func newUUIDs(g Generator, n int) ([]UUID, error) {
+	if n < 0 {
+		return nil, fmt.Errorf("uuid: negative batch size: %d", n)
+	}
+
+	if n == 0 {
+		return nil, nil
+	}
+
+	uids := make([]UUID, n)
+	for i := range uids {
+		uid, err := g.NewUUID()
+		if err != nil {
+			return nil, err
+		}
+		uids[i] = uid
+	}
+	return uids, nil
+}
+
+// NewString generates a new UUID with the default generator and returns its
+// canonical string, see New.
+func NewString() (string, error) {
+	return NewUUIDString(DefaultGenerator())
+}
+
+// MustNewString is like NewString but panics if the UUID can't be generated.
+func MustNewString() string {
+	s, err := NewString()
+	if err != nil {
+		panic(`uuid: MustNewString: ` + err.Error())
+	}
+	return s
+}
+
+// NewUUIDString generates a new UUID with the given generator and returns its
+// canonical string. It works with any Generator, including custom ones.
+func NewUUIDString(g Generator) (string, error) {
+	uid, err := g.NewUUID()
+	if err != nil {
+		return "", err
+	}
+	return uid.String(), nil
+}
+
+// defaultVersions maps the versions accepted by SetDefaultVersion to the
+// constructor of their generator.
+var defaultVersions = map[int]func() Generator{
+	4: func() Generator { return NewV4Generator(SecureReader) },
+	7: func() Generator { return NewV7Generator(SecureReader) },
+}
+
+// SetDefaultVersion replaces the default generator used by New with the
+// generator of the given version using rand.Reader. Only versions that need
+// no configuration are accepted, so the name-based versions (v3, v5) and the
+// node-based v1 are rejected with an error.
+func SetDefaultVersion(v int) error {
+	newGenerator, ok := defaultVersions[v]
+	if !ok {
+		return fmt.Errorf("uuid: unsupported default version: %d", v)
+	}
+
+	SetDefaultGenerator(newGenerator())
+	return nil
+}
+
+// SetDefaultGenerator replaces the default generator used by New, NewString
+// and NewN, e.g. with a V7Generator at startup. The change is process-wide:
+// all the subsequent calls, from any goroutine, use the new generator, so it
+// must be safe for concurrent use. A nil generator restores the default
+// V4Generator using rand.Reader.
+func SetDefaultGenerator(g Generator) {
+	if g == nil {
+		g = NewV4Generator(SecureReader)
+	}
+
+	defaultMutex.Lock()
+	defaultGenerator = g
+	defaultMutex.Unlock()
+}
+
+// DefaultGenerator returns the default generator used by New.
+func DefaultGenerator() Generator {
+	defaultMutex.RLock()
+	defer defaultMutex.RUnlock()
+	return defaultGenerator
+}
 
 // fillUUID fills uuid with random byte from the given reader.
 func fillUUID(reader io.Reader) (UUID, error) {
@@ -101,6 +370,31 @@ func StaticReader() io.Reader {
 	})
 }
 
+// FromInt returns an uuid with its last 8 bytes set to n in big-endian and
+// the first 8 bytes set to zero, e.g. FromInt(5) is
+// 00000000-0000-0000-0000-000000000005. It's a readable fixture value and
+// doesn't satisfy any UUID version. This is useful only for testing.
+func FromInt(n uint64) UUID {
+	return fromUint128(0, n)
+}
+
+// staticUID is the parsed value of StaticUUID.
+var staticUID, _ = Parse(StaticUUID)
+
+// IsTestValue reports whether the uuid looks like a fixture produced by this
+// package's test helpers: the StaticUUID, or a low integer uuid such as the
+// ones returned by FromInt, whose first 8 bytes are zero. This heuristic
+// also flags Nil. The chance for a random UUID to be flagged is 2^-64, so
+// production code can use it to reject accidentally persisted test data.
+func IsTestValue(uid UUID) bool {
+	if uid == staticUID {
+		return true
+	}
+
+	hi, _ := toUint128(uid)
+	return hi == 0
+}
+
 type eofReader struct{}
 
 func (*eofReader) Read(_ []byte) (n int, err error) { return 0, io.EOF }
@@ -109,6 +403,72 @@ func (*eofReader) Read(_ []byte) (n int, err error) { return 0, io.EOF }
 // This is useful only for testing.
 func ErrorsReader() io.Reader { return &eofReader{} }
 
+// sequentialReader is a stream of big-endian 128-bit counters incremented
+// from 1, guarded by a mutex.
+type sequentialReader struct {
+	mu   sync.Mutex
+	last UUID
+	off  int
+}
+
+func (r *sequentialReader) Read(p []byte) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for i := range p {
+		if r.off == len(r.last) {
+			r.last = r.last.Increment()
+			r.off = 0
+		}
+
+		p[i] = r.last[r.off]
+		r.off++
+	}
+	return len(p), nil
+}
+
+// SequentialReader returns a ReaderFactory whose readers share a stream of
+// 16-byte big-endian counters starting at 1, so the generators using it
+// produce distinct, ordered and predictable UUIDs, e.g. the first ones of a
+// V4Generator are 00000000-0000-4000-8000-000000000001,
+// 00000000-0000-4000-8000-000000000002 and so on. Unlike StaticReader it
+// must be called, each call starting a new stream:
+//
+//	v4 := NewV4Generator(SequentialReader())
+//
+// It returns a ReaderFactory rather than an io.Reader because the generators
+// call their factory for each UUID: a fresh reader per call would restart
+// the counter, and a package-level one would make the values depend on the
+// other tests.
+//
+// This is useful only for testing.
+func SequentialReader() ReaderFactory {
+	r := &sequentialReader{off: len(Nil)}
+	return func() io.Reader { return r }
+}
+
+// seededReader is a reader of pseudo-random bytes from a math/rand source,
+// guarded by a mutex since a math/rand.Rand is not safe for concurrent use.
+type seededReader struct {
+	mu  sync.Mutex
+	rnd *mathrand.Rand
+}
+
+func (r *seededReader) Read(p []byte) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.rnd.Read(p)
+}
+
+// NewSeededV4Generator creates a new instance of V4Generator reading from a
+// math/rand source seeded with seed, so the same seed always gives the same
+// sequence of distinct UUIDs. The UUIDs are predictable, it's NOT
+// cryptographically secure. This is useful only for testing.
+func NewSeededV4Generator(seed int64) *V4Generator {
+	r := &seededReader{rnd: mathrand.New(mathrand.NewSource(seed))}
+	return NewV4Generator(func() io.Reader { return r })
+}
+
 // V4Generator generates version 4 UUIDs using a random number generator factory.
 type V4Generator struct {
 	factory ReaderFactory
@@ -122,6 +482,13 @@ func NewV4Generator(factory ReaderFactory) *V4Generator {
 	}
 }
 
+// Concurrent returns true, V4Generator holds no state. The readers returned
+// by the factory must be safe for concurrent use or distinct for each call,
+// as the built-in ones are.
+func (v *V4Generator) Concurrent() bool {
+	return true
+}
+
 // NewUUID generates a new UUID by filling it with random data using the
 // factory and setting the version and variant bits to satisfy the UUID v4
 // standard.
@@ -131,9 +498,41 @@ func (v *V4Generator) NewUUID() (UUID, error) {
 		return Nil, err
 	}
 
+	SetV4Bits(&uid)
+	return uid, err
+}
+
+// NewUUIDs generates n new UUIDs with the random data of all of them read at
+// once from the factory. On any error it returns nil, never a partial slice.
+func (v *V4Generator) NewUUIDs(n int) ([]UUID, error) {
+	if n < 0 {
+		return nil, fmt.Errorf("uuid: negative batch size: %d", n)
+	}
+
+	if n == 0 {
+		return nil, nil
+	}
+
+	buf := make([]byte, 16*n)
+	if _, err := io.ReadFull(v.factory(), buf); err != nil {
+		return nil, err
+	}
+
+	uids := make([]UUID, n)
+	for i := range uids {
+		copy(uids[i][:], buf[i*16:])
+		SetV4Bits(&uids[i])
+	}
+	return uids, nil
+}
+
+// SetV4Bits sets the version and variant bits of the given uuid in place to
+// satisfy the UUID v4 standard, leaving the other bits untouched. It lets
+// callers reusing their own random buffers turn them into UUID v4 without
+// copying.
+func SetV4Bits(uid *UUID) {
 	uid[6] = (uid[6] & 0x0f) | 0x40 // Version 4
 	uid[8] = (uid[8] & 0x3f) | 0x80 // Variant is 10
-	return uid, err
 }
 
 // hexValues returns the value of a byte as a hexadecimal digit or 0xff.
@@ -167,6 +566,72 @@ var hexStartedIndex = [16]int{
 	24, 26, 28, 30, 32, 34, // kk, ll, mm, nn, oo, pp
 }
 
+// Parsing errors are returned as is, without formatting the input, so the
+// common failure modes don't allocate. Use ParseVerbose to get the details.
+var (
+	// ErrLength is returned when the input doesn't have the expected length.
+	ErrLength = errors.New("uuid: incorrect UUID length")
+	// ErrFormat is returned when the braces or the prefix are misplaced. The
+	// misplaced dashes give ErrInvalidDashes, which matches ErrFormat.
+	ErrFormat = errors.New("uuid: incorrect UUID format")
+	// ErrChar is returned when the input contains an invalid hex character.
+	ErrChar = errors.New("uuid: invalid UUID character")
+
+	// ErrInvalidLength is ErrLength, named to match ErrInvalidDashes.
+	ErrInvalidLength = ErrLength
+	// ErrInvalidFormat is ErrFormat, named to match ErrInvalidDashes.
+	ErrInvalidFormat = ErrFormat
+	// ErrInvalidDashes is returned when the dashes of the canonical form are
+	// misplaced. It's a more specific ErrFormat: errors.Is(err, ErrFormat) is
+	// true too.
+	ErrInvalidDashes error = dashesError{}
+)
+
+// dashesError is the type of ErrInvalidDashes.
+type dashesError struct{}
+
+func (dashesError) Error() string { return "uuid: misplaced UUID dashes" }
+
+// Is reports whether the target is ErrFormat.
+func (dashesError) Is(target error) bool { return target == ErrFormat }
+
+// ParseError is returned by ParseVerbose, it carries the invalid input and
+// the position of the first invalid byte. It wraps one of the parsing
+// errors, so the cause can be inspected with errors.Is:
+//
+//	var perr *ParseError
+//	if errors.As(err, &perr) && errors.Is(perr, ErrChar) {
+//		// invalid character at perr.Pos
+//	}
+type ParseError struct {
+	Input string // Input is the string being parsed.
+	Pos   int    // Pos is the offset of the invalid byte, -1 if the length is wrong.
+	Err   error  // Err is ErrLength, ErrInvalidDashes or ErrChar.
+}
+
+// Error returns a message describing the error and its position. The
+// invalid byte is only reported when Pos is within Input.
+func (e *ParseError) Error() string {
+	if e.Err == nil {
+		return "uuid: invalid UUID"
+	}
+
+	inInput := e.Pos >= 0 && e.Pos < len(e.Input)
+	switch {
+	case e.Err == ErrLength:
+		return fmt.Sprintf("uuid: incorrect UUID length %d, expected 36", len(e.Input))
+	case e.Err == ErrInvalidDashes && inInput:
+		return fmt.Sprintf("uuid: expected '-' at position %d, got %q", e.Pos, e.Input[e.Pos])
+	case e.Err == ErrChar && inInput:
+		return fmt.Sprintf("uuid: invalid character %q at position %d", e.Input[e.Pos], e.Pos)
+	default:
+		return e.Err.Error()
+	}
+}
+
+// Unwrap returns the cause of the error.
+func (e *ParseError) Unwrap() error { return e.Err }
+
 // Parse parses a UUID from a string.
 // The string may be in any of the following formats:
 //
@@ -174,25 +639,68 @@ var hexStartedIndex = [16]int{
 func Parse(s string) (UUID, error) {
 
 	if len(s) != 36 {
-		return Nil, fmt.Errorf("uuid: incorrect UUID length: %s", s)
+		return Nil, ErrLength
 	}
 
-	if s[8] != '-' || s[13] != '-' || s[18] != '-' || s[23] != '-' {
-		return Nil, fmt.Errorf("uuid: expected dashes at positions 8, 13, 18, and 23")
+	if !hasDashes(s) {
+		return Nil, ErrInvalidDashes
 	}
 
 	return parse(s, hexStartedIndex)
 }
 
-// parse do the actual parsing of a UUID from a string.
-func parse(s string, indexes [16]int) (UUID, error) {
+// MustParse is like Parse but panics if the string can't be parsed. It
+// simplifies the safe initialization of global variables holding UUIDs, and
+// of test fixtures.
+func MustParse(s string) UUID {
+	uid, err := Parse(s)
+	if err != nil {
+		panic(`uuid: MustParse(` + strconv.Quote(s) + `): ` + err.Error())
+	}
+	return uid
+}
+
+// Must returns uid, or panics if err is not nil. It wraps a call returning
+// a UUID and an error where a failure is a programming error, e.g.
+//
+//	var id = uuid.Must(uuid.New())
+func Must(uid UUID, err error) UUID {
+	if err != nil {
+		panic(`uuid: Must: ` + err.Error())
+	}
+	return uid
+}
+
+// ParseBytes parses a UUID from a byte slice in the canonical form, as Parse
+// does, returning the same errors. The bytes are decoded in place, without
+// the allocation of Parse(string(b)).
+func ParseBytes(b []byte) (UUID, error) {
+	if len(b) != 36 {
+		return Nil, ErrLength
+	}
+
+	if !hasDashes(b) {
+		return Nil, ErrInvalidDashes
+	}
+
+	return parse(b, hexStartedIndex)
+}
+
+// hasDashes reports whether s has dashes at the positions of the canonical
+// form, s must be at least 24 bytes long.
+func hasDashes[T string | []byte](s T) bool {
+	return s[8] == '-' && s[13] == '-' && s[18] == '-' && s[23] == '-'
+}
+
+// parse do the actual parsing of a UUID from a string or a byte slice.
+func parse[T string | []byte](s T, indexes [16]int) (UUID, error) {
 	var uid UUID
 	for i, start := range indexes {
 		// start+1 is the index of the second hex character.
 		// for hex, it's take 2 bytes for 1 hex character.
 		v, ok := hexToByte(s[start], s[start+1])
 		if !ok {
-			return Nil, fmt.Errorf("uuid: invalid UUID string: %s", s)
+			return Nil, ErrChar
 		}
 
 		uid[i] = v
@@ -1,6 +1,8 @@
 package uuid
 
 import (
+	"bytes"
+	"errors"
 	"fmt"
 	"io"
 	"strings"
@@ -49,6 +51,27 @@ func ExampleNewV4Generator() {
 	// static true
 }
 
+func ExampleNewSeededV4Generator() {
+	// the same seed always gives the same sequence.
+	for run := 0; run < 2; run++ {
+		v4 := NewSeededV4Generator(42)
+		for i := 0; i < 2; i++ {
+			uid, err := v4.NewUUID()
+			if err != nil {
+				panic(err)
+			}
+
+			fmt.Println(run, uid)
+		}
+	}
+
+	// Output:
+	// 0 538c7f96-b164-4f1b-97bb-9f4bb472e89f
+	// 0 5b1484f2-5209-49d9-b43e-92ba09dd9d52
+	// 1 538c7f96-b164-4f1b-97bb-9f4bb472e89f
+	// 1 5b1484f2-5209-49d9-b43e-92ba09dd9d52
+}
+
 func TestNew(t *testing.T) {
 	uid1 := must(t, New)
 	if uid1 == Nil {
@@ -65,6 +88,220 @@ func TestNew(t *testing.T) {
 	}
 }
 
+func TestNewString(t *testing.T) {
+	s, err := NewString()
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	uid, err := Parse(s)
+	if err != nil || !IsV4(uid) || uid == Nil {
+		t.Fatal("unexpected uuid:", s)
+	}
+
+	if s2 := MustNewString(); s2 == s {
+		t.Fatal("unexpected equal uuid:", s2)
+	}
+}
+
+func TestNewUUIDString(t *testing.T) {
+	s, err := NewUUIDString(NewV4Generator(StaticReader))
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	if s != StaticUUID {
+		t.Fatal("unexpected uuid:", s)
+	}
+
+	s, err = NewUUIDString(NewV4Generator(ErrorsReader))
+	if err != io.EOF || s != "" {
+		t.Fatal("unexpected result:", s, err)
+	}
+}
+
+func TestNewN(t *testing.T) {
+	uids, err := NewN(100)
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	seen := make(map[UUID]bool)
+	for _, uid := range uids {
+		if !IsV4(uid) || seen[uid] {
+			t.Fatal("unexpected uuid:", uid)
+		}
+		seen[uid] = true
+	}
+
+	if len(seen) != 100 {
+		t.Fatal("unexpected number of uuids:", len(seen))
+	}
+
+	if uids, err := NewN(0); err != nil || uids != nil {
+		t.Fatal("unexpected result:", uids, err)
+	}
+
+	if uids, err := NewN(-1); err == nil || uids != nil {
+		t.Fatal("expected error, got nil")
+	}
+}
+
+func TestV4Generator_NewUUIDs(t *testing.T) {
+	// each uuid is made of its own 16 bytes of the reader.
+	reader := bytes.NewReader(append(make([]byte, 16), bytes.Repeat([]byte{0xff}, 16)...))
+	uids, err := NewV4Generator(func() io.Reader { return reader }).NewUUIDs(2)
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	if len(uids) != 2 || uids[0].String() != "00000000-0000-4000-8000-000000000000" || uids[1].String() != "ffffffff-ffff-4fff-bfff-ffffffffffff" {
+		t.Fatal("unexpected uuids:", uids)
+	}
+
+	if uids, err := NewV4Generator(ErrorsReader).NewUUIDs(2); err != io.EOF || uids != nil {
+		t.Fatal("unexpected result:", uids, err)
+	}
+}
+
+func TestNewUUIDs_Loop(t *testing.T) {
+	g := &countingGenerator{Generator: NewV4Generator(SecureReader)}
+	uids, err := newUUIDs(g, 3)
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	if len(uids) != 3 || g.calls != 3 {
+		t.Fatal("unexpected uuids:", uids)
+	}
+
+	if uids, err := newUUIDs(NewV4Generator(ErrorsReader), 3); err != io.EOF || uids != nil {
+		t.Fatal("unexpected result:", uids, err)
+	}
+}
+
+func BenchmarkNewN(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		if _, err := NewN(100); err != nil {
+			b.Fatal("unexpected error:", err)
+		}
+	}
+}
+
+func BenchmarkNewN_Loop(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		uids := make([]UUID, 100)
+		for j := range uids {
+			uid, err := New()
+			if err != nil {
+				b.Fatal("unexpected error:", err)
+			}
+			uids[j] = uid
+		}
+	}
+}
+
+func TestSetDefaultVersion(t *testing.T) {
+	t.Cleanup(func() {
+		if err := SetDefaultVersion(4); err != nil {
+			t.Fatal("unexpected error:", err)
+		}
+	})
+
+	if err := SetDefaultVersion(7); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	uid := must(t, New)
+	if uid == Nil || !IsV7(uid) {
+		t.Fatal("unexpected uuid:", uid)
+	}
+
+	if err := SetDefaultVersion(4); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	uid = must(t, New)
+	if uid == Nil || !IsV4(uid) {
+		t.Fatal("unexpected uuid:", uid)
+	}
+}
+
+func TestSetDefaultVersion_NewN(t *testing.T) {
+	t.Cleanup(func() {
+		if err := SetDefaultVersion(4); err != nil {
+			t.Fatal("unexpected error:", err)
+		}
+	})
+
+	if err := SetDefaultVersion(7); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	uids, err := NewN(100)
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	for i, uid := range uids {
+		if !IsV7(uid) || (i > 0 && uid.Compare(uids[i-1]) <= 0) {
+			t.Fatal("unexpected uuid:", uid)
+		}
+	}
+}
+
+func TestSetDefaultGenerator(t *testing.T) {
+	t.Cleanup(func() { SetDefaultGenerator(nil) })
+
+	g := &countingGenerator{Generator: NewV4Generator(StaticReader)}
+	SetDefaultGenerator(g)
+	if DefaultGenerator() != g {
+		t.Fatal("unexpected default generator:", DefaultGenerator())
+	}
+
+	if uid := must(t, New); uid.String() != StaticUUID {
+		t.Fatal("unexpected uuid:", uid)
+	}
+
+	if s, err := NewString(); err != nil || s != StaticUUID {
+		t.Fatal("unexpected uuid:", s, err)
+	}
+
+	// not a BatchGenerator, NewN falls back to a loop.
+	if uids, err := NewN(2); err != nil || len(uids) != 2 || uids[1].String() != StaticUUID {
+		t.Fatal("unexpected uuids:", uids, err)
+	}
+
+	if g.calls != 4 {
+		t.Fatal("unexpected number of calls:", g.calls)
+	}
+
+	SetDefaultGenerator(nil)
+	if _, ok := DefaultGenerator().(*V4Generator); !ok {
+		t.Fatal("unexpected default generator:", DefaultGenerator())
+	}
+
+	if uid := must(t, New); !IsV4(uid) || uid.String() == StaticUUID {
+		t.Fatal("unexpected uuid:", uid)
+	}
+}
+
+func TestSetDefaultVersion_Errors(t *testing.T) {
+	for _, v := range []int{0, 1, 3, 5, 9} {
+		if err := SetDefaultVersion(v); err == nil {
+			t.Fatalf("expected error for version %d, got nil", v)
+		}
+	}
+
+	// the default generator is left untouched.
+	uid := must(t, New)
+	if !IsV4(uid) {
+		t.Fatal("unexpected uuid:", uid)
+	}
+}
+
 func TestNewV4_SecureReader(t *testing.T) {
 	v4 := NewV4Generator(SecureReader)
 
@@ -101,6 +338,54 @@ func TestNewV4_StaticReader(t *testing.T) {
 	}
 }
 
+func TestNewSeededV4Generator(t *testing.T) {
+	a, b := NewSeededV4Generator(1), NewSeededV4Generator(1)
+	other := NewSeededV4Generator(2)
+
+	seen := make(map[UUID]bool)
+	for i := 0; i < 100; i++ {
+		uid := must(t, a.NewUUID)
+		if !IsV4(uid) || seen[uid] {
+			t.Fatal("unexpected uuid:", uid)
+		}
+		seen[uid] = true
+
+		if got := must(t, b.NewUUID); got != uid {
+			t.Fatal("unexpected uuid for the same seed:", got)
+		}
+
+		if got := must(t, other.NewUUID); got == uid {
+			t.Fatal("unexpected uuid for another seed:", got)
+		}
+	}
+}
+
+func TestSequentialReader(t *testing.T) {
+	v4 := NewV4Generator(SequentialReader())
+	for i := uint64(1); i <= 300; i++ {
+		want := FromInt(i)
+		SetV4Bits(&want)
+		if uid := must(t, v4.NewUUID); uid != want {
+			t.Fatalf("unexpected uuid: %s, want %s", uid, want)
+		}
+	}
+
+	// each call starts a new stream, shared by the readers of the factory.
+	factory := SequentialReader()
+	uids, err := NewV4Generator(factory).NewUUIDs(3)
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	if uids[0].String() != "00000000-0000-4000-8000-000000000001" || uids[2].String() != "00000000-0000-4000-8000-000000000003" {
+		t.Fatal("unexpected uuids:", uids)
+	}
+
+	if uid := must(t, NewV4Generator(factory).NewUUID); uid.String() != "00000000-0000-4000-8000-000000000004" {
+		t.Fatal("unexpected uuid:", uid)
+	}
+}
+
 func TestNewV4_ErrorsReader(t *testing.T) {
 	v4 := NewV4Generator(ErrorsReader)
 	_, err := v4.NewUUID()
@@ -109,6 +394,26 @@ func TestNewV4_ErrorsReader(t *testing.T) {
 	}
 }
 
+func TestSetV4Bits(t *testing.T) {
+	raw := must(t, func() (UUID, error) { return fillUUID(StaticReader()) })
+	want := must(t, NewV4Generator(StaticReader).NewUUID)
+
+	uid := raw
+	SetV4Bits(&uid)
+	if uid != want {
+		t.Fatal("unexpected uuid:", uid)
+	}
+
+	// takes 100 random samples.
+	for i := 0; i < 100; i++ {
+		uid = must(t, func() (UUID, error) { return fillUUID(SecureReader()) })
+		SetV4Bits(&uid)
+		if !IsV4(uid) {
+			t.Fatal("unexpected uuid:", uid)
+		}
+	}
+}
+
 func TestUUID_String(t *testing.T) {
 	v4 := NewV4Generator(StaticReader)
 	uid := must(t, v4.NewUUID)
@@ -117,6 +422,83 @@ func TestUUID_String(t *testing.T) {
 	}
 }
 
+func TestUUID_StringUpper(t *testing.T) {
+	uid := MustParse(StaticUUID)
+	if got := uid.StringUpper(); got != "00010203-0405-4607-8809-0A0B0C0D0E0F" {
+		t.Fatal("unexpected string:", got)
+	}
+
+	// takes 100 random samples.
+	for i := 0; i < 100; i++ {
+		uid := must(t, New)
+		if got, want := uid.StringUpper(), strings.ToUpper(uid.String()); got != want {
+			t.Fatalf("unexpected string: %s, want %s", got, want)
+		}
+
+		back := must(t, func() (UUID, error) { return Parse(uid.StringUpper()) })
+		if back != uid {
+			t.Fatal("unexpected uuid:", back)
+		}
+	}
+}
+
+func TestUUID_AppendString(t *testing.T) {
+	uid := MustParse(StaticUUID)
+	if got := string(uid.AppendString([]byte("id="))); got != "id="+StaticUUID {
+		t.Fatal("unexpected string:", got)
+	}
+
+	if got := string(Nil.AppendString(nil)); got != Nil.String() {
+		t.Fatal("unexpected string:", got)
+	}
+}
+
+func BenchmarkUUID_AppendString(b *testing.B) {
+	uid := MustParse(StaticUUID)
+	buf := make([]byte, 0, 36)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		buf = uid.AppendString(buf[:0])
+	}
+}
+
+func BenchmarkUUID_String(b *testing.B) {
+	uid := MustParse(StaticUUID)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_ = uid.String()
+	}
+}
+
+func TestFromInt(t *testing.T) {
+	if uid := FromInt(5); uid.String() != "00000000-0000-0000-0000-000000000005" {
+		t.Fatal("unexpected uuid:", uid)
+	}
+
+	if uid := FromInt(0); uid != Nil {
+		t.Fatal("unexpected uuid:", uid)
+	}
+}
+
+func TestIsTestValue(t *testing.T) {
+	v4 := NewV4Generator(StaticReader)
+	if uid := must(t, v4.NewUUID); !IsTestValue(uid) {
+		t.Fatal("static uuid should be a test value:", uid)
+	}
+
+	if !IsTestValue(FromInt(5)) {
+		t.Fatal("low integer uuid should be a test value:", FromInt(5))
+	}
+
+	// takes 100 random samples.
+	for i := 0; i < 100; i++ {
+		uid := must(t, New)
+		if IsTestValue(uid) {
+			t.Fatal("random uuid should not be a test value:", uid)
+		}
+	}
+}
+
 func TestIsV4(t *testing.T) {
 	if !IsV4(Nil) {
 		t.Error("Nil should be a valid v4 uuid")
@@ -154,6 +536,255 @@ func TestIsV4_Errors(t *testing.T) {
 	}
 }
 
+func TestUUID_Version(t *testing.T) {
+	v1, err := NewV1Generator(nil, SecureReader)
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	table := []struct {
+		name string
+		g    Generator
+		want int
+	}{
+		{"v1", v1, 1},
+		{"v4", NewV4Generator(SecureReader), 4},
+		{"v5", NewV5Generator(NamespaceDNS, []byte("www.example.com")), 5},
+		{"v7", NewV7Generator(SecureReader), 7},
+		{"v8", NewV8Nanos(SecureReader), 8},
+	}
+
+	for _, tt := range table {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			if got := must(t, tt.g.NewUUID).Version(); got != tt.want {
+				t.Fatalf("unexpected version: %d, want %d", got, tt.want)
+			}
+		})
+	}
+
+	if v := NewV3(NamespaceDNS, nil).Version(); v != 3 {
+		t.Fatal("unexpected version:", v)
+	}
+
+	if v := Nil.Version(); v != 0 {
+		t.Fatal("unexpected nil version:", v)
+	}
+
+	if v := Max.Version(); v != 15 {
+		t.Fatal("unexpected max version:", v)
+	}
+}
+
+func TestUUID_Variant(t *testing.T) {
+	table := []struct {
+		b    byte
+		want Variant
+		name string
+	}{
+		{0x00, VariantNCS, "NCS"},
+		{0x7f, VariantNCS, "NCS"},
+		{0x80, VariantRFC4122, "RFC4122"},
+		{0xbf, VariantRFC4122, "RFC4122"},
+		{0xc0, VariantMicrosoft, "Microsoft"},
+		{0xdf, VariantMicrosoft, "Microsoft"},
+		{0xe0, VariantFuture, "Future"},
+		{0xff, VariantFuture, "Future"},
+	}
+
+	for _, tt := range table {
+		tt := tt
+		t.Run(fmt.Sprintf("%#02x", tt.b), func(t *testing.T) {
+			var uid UUID
+			uid[8] = tt.b
+			if got := uid.Variant(); got != tt.want || got.String() != tt.name {
+				t.Fatalf("unexpected variant: %s, want %s", got, tt.want)
+			}
+		})
+	}
+
+	if v := must(t, New).Variant(); v != VariantRFC4122 {
+		t.Fatal("unexpected variant:", v)
+	}
+
+	if s := Variant(-1).String(); s != "unknown" {
+		t.Fatal("unexpected variant name:", s)
+	}
+}
+
+func TestUUID_VersionIn(t *testing.T) {
+	uid := must(t, New)
+	if !uid.VersionIn(4) || !uid.VersionIn(1, 4, 7) {
+		t.Fatal("version should be in the list:", uid)
+	}
+
+	if uid.VersionIn(1, 7) {
+		t.Fatal("version should not be in the list:", uid)
+	}
+
+	if uid.VersionIn() {
+		t.Fatal("version should not be in an empty list:", uid)
+	}
+
+	if !Nil.VersionIn(0) {
+		t.Fatal("nil version should be 0")
+	}
+}
+
+func TestValidate(t *testing.T) {
+	v7 := must(t, NewV7Generator(SecureReader).NewUUID)
+	table := []struct {
+		name    string
+		in      UUID
+		allowed []int
+	}{
+		{"v4 any", MustParse(StaticUUID), nil},
+		{"v4 allowed", MustParse(StaticUUID), []int{4, 7}},
+		{"v7 allowed", v7, []int{4, 7}},
+		{"v5 any", NewV5(NamespaceDNS, []byte("www.example.com")), nil},
+		{"v8 any", NewV8([16]byte{}), nil},
+	}
+
+	for _, tt := range table {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			if err := Validate(tt.in, tt.allowed...); err != nil {
+				t.Fatal("unexpected error:", err)
+			}
+		})
+	}
+}
+
+func TestValidate_Errors(t *testing.T) {
+	table := []struct {
+		name    string
+		in      UUID
+		allowed []int
+	}{
+		{"nil", Nil, nil},
+		{"nil allowed version", Nil, []int{0}},
+		{"max", Max, nil},
+		{"version not allowed", MustParse(StaticUUID), []int{7}},
+		{"unknown version", MustParse("00000000-0000-9000-8000-000000000001"), nil},
+		{"version zero", MustParse("00000000-0000-0000-8000-000000000001"), nil},
+		{"microsoft variant", MustParse("00010203-0405-4607-c809-0a0b0c0d0e0f"), []int{4}},
+		{"ncs variant", MustParse("00010203-0405-4607-0809-0a0b0c0d0e0f"), nil},
+	}
+
+	for _, tt := range table {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			if err := Validate(tt.in, tt.allowed...); err == nil {
+				t.Fatal("expected error, got nil")
+			}
+		})
+	}
+}
+
+func TestMax(t *testing.T) {
+	if Max.String() != "ffffffff-ffff-ffff-ffff-ffffffffffff" {
+		t.Fatal("unexpected max uuid:", Max)
+	}
+
+	if !IsMax(Max) || !Max.IsMax() || Max.IsNil() {
+		t.Fatal("Max should be the max uuid")
+	}
+
+	if IsMax(Nil) || Nil.IsMax() || !Nil.IsNil() {
+		t.Fatal("Nil should be the nil uuid")
+	}
+
+	uid := must(t, New)
+	if IsMax(uid) || uid.IsMax() || uid.IsNil() {
+		t.Fatal("unexpected uuid:", uid)
+	}
+
+	if max := must(t, func() (UUID, error) { return Parse("FFFFFFFF-FFFF-FFFF-FFFF-FFFFFFFFFFFF") }); !max.IsMax() {
+		t.Fatal("unexpected uuid:", max)
+	}
+}
+
+func TestIsV7(t *testing.T) {
+	if !IsV7(Nil) {
+		t.Error("Nil should be a valid v7 uuid")
+	}
+
+	v7 := NewV7Generator(StaticReader)
+	uid := must(t, v7.NewUUID)
+	if !IsV7(uid) {
+		t.Fatal("unexpected uuid:", uid)
+	}
+
+	// takes 100 random samples.
+	for i := 0; i < 100; i++ {
+		uid = must(t, v7.NewUUID)
+		if !IsV7(uid) || IsV4(uid) {
+			t.Fatal("unexpected uuid:", uid)
+		}
+
+		if v4 := must(t, New); IsV7(v4) {
+			t.Fatal("unexpected uuid:", v4)
+		}
+	}
+}
+
+func TestIsVersion(t *testing.T) {
+	v1, err := NewV1Generator(nil, SecureReader)
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	v6, err := NewV6Generator(nil, SecureReader)
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	table := []struct {
+		name    string
+		uid     UUID
+		version int
+		is      func(UUID) bool
+	}{
+		{"v1", must(t, v1.NewUUID), 1, IsV1},
+		{"v3", NewV3(NamespaceDNS, []byte("www.example.com")), 3, IsV3},
+		{"v4", must(t, New), 4, IsV4},
+		{"v5", NewV5(NamespaceDNS, []byte("www.example.com")), 5, IsV5},
+		{"v6", must(t, v6.NewUUID), 6, IsV6},
+		{"v7", must(t, NewV7Generator(SecureReader).NewUUID), 7, IsV7},
+	}
+
+	for _, tt := range table {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			if !tt.is(Nil) || !IsVersion(Nil, tt.version) {
+				t.Errorf("Nil should be a valid v%d uuid", tt.version)
+			}
+
+			if !tt.is(tt.uid) || !IsVersion(tt.uid, tt.version) {
+				t.Fatal("unexpected uuid:", tt.uid)
+			}
+
+			// only the predicate of its own version matches.
+			for _, other := range table {
+				if other.version != tt.version && (other.is(tt.uid) || IsVersion(tt.uid, other.version)) {
+					t.Fatalf("uuid should not be a v%d uuid: %s", other.version, tt.uid)
+				}
+			}
+
+			// the variant bits is not 10 in binary.
+			uid := tt.uid
+			uid[8] = uid[8]&0x3f | 0xc0
+			if tt.is(uid) || IsVersion(uid, tt.version) {
+				t.Errorf("it should not be a v%d uuid", tt.version)
+			}
+		})
+	}
+
+	if IsVersion(Max, 15) {
+		t.Error("Max should not be a valid uuid")
+	}
+}
+
 func TestParse(t *testing.T) {
 	v4 := NewV4Generator(StaticReader)
 	uid := must(t, v4.NewUUID)
@@ -176,15 +807,16 @@ func TestParse_Errors(t *testing.T) {
 	table := []struct {
 		name string
 		in   string
+		err  error
 	}{
-		{"empty", ""},
-		{"short", "12345678-1234-1234-1234-1234567890"},
-		{"long", "12345678-1234-1234-1234-1234567890123"},
-		{"invalid chars", "12345678-1234-1234-1234-12345678901g"},
-		{"only dashes", "------------------------------------"},
-		{"invalid dashes position", "123456781234-1234-1234-1234567890120"},
-		{"invalid dashes position", "-12345678-1234-1234-12341234567890120"},
-		{"no dashes", strings.Repeat("a", 36)},
+		{"empty", "", ErrLength},
+		{"short", "12345678-1234-1234-1234-1234567890", ErrLength},
+		{"long", "12345678-1234-1234-1234-1234567890123", ErrLength},
+		{"invalid chars", "12345678-1234-1234-1234-12345678901g", ErrChar},
+		{"only dashes", "------------------------------------", ErrChar},
+		{"invalid dashes position", "123456781234-1234-1234-1234567890120", ErrInvalidDashes},
+		{"invalid dashes position", "-12345678-1234-1234-12341234567890120", ErrLength},
+		{"no dashes", strings.Repeat("a", 36), ErrInvalidDashes},
 	}
 
 	for _, tt := range table {
@@ -195,9 +827,166 @@ func TestParse_Errors(t *testing.T) {
 				t.Fatal("expected error, got nil")
 			}
 
+			if !errors.Is(err, tt.err) {
+				t.Fatal("unexpected error:", err)
+			}
+
+			if uid != Nil {
+				t.Fatal("unexpected nil uuid:", uid)
+			}
+		})
+	}
+}
+
+func TestMustParse(t *testing.T) {
+	if uid := MustParse(StaticUUID); uid.String() != StaticUUID {
+		t.Fatal("unexpected uuid:", uid)
+	}
+
+	defer func() {
+		msg, _ := recover().(string)
+		if msg != `uuid: MustParse("not-a-uuid"): uuid: incorrect UUID length` {
+			t.Fatal("unexpected panic:", msg)
+		}
+	}()
+
+	MustParse("not-a-uuid")
+	t.Fatal("expected panic")
+}
+
+func TestMust(t *testing.T) {
+	uid := must(t, NewV4Generator(StaticReader).NewUUID)
+	if got := Must(uid, nil); got != uid {
+		t.Fatal("unexpected uuid:", got)
+	}
+
+	defer func() {
+		msg, _ := recover().(string)
+		if msg != "uuid: Must: EOF" {
+			t.Fatal("unexpected panic:", msg)
+		}
+	}()
+
+	Must(NewV4Generator(ErrorsReader).NewUUID())
+	t.Fatal("expected panic")
+}
+
+func TestParseBytes(t *testing.T) {
+	uid, err := ParseBytes([]byte(StaticUUID))
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	if uid.String() != StaticUUID {
+		t.Fatal("unexpected uuid:", uid)
+	}
+
+	// takes 100 random samples.
+	for i := 0; i < 100; i++ {
+		want := must(t, New)
+		if got := must(t, func() (UUID, error) { return ParseBytes([]byte(want.String())) }); got != want {
+			t.Fatal("unexpected uuid:", got)
+		}
+	}
+}
+
+func TestParseBytes_Errors(t *testing.T) {
+	table := []struct {
+		name string
+		in   string
+		err  error
+	}{
+		{"empty", "", ErrLength},
+		{"short", "12345678-1234-1234-1234-1234567890", ErrLength},
+		{"long", "12345678-1234-1234-1234-1234567890123", ErrLength},
+		{"invalid chars", "12345678-1234-1234-1234-12345678901g", ErrChar},
+		{"only dashes", "------------------------------------", ErrChar},
+		{"invalid dashes position", "123456781234-1234-1234-1234567890120", ErrInvalidDashes},
+		{"no dashes", strings.Repeat("a", 36), ErrInvalidDashes},
+	}
+
+	for _, tt := range table {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			uid, err := ParseBytes([]byte(tt.in))
+			if err != tt.err {
+				t.Fatal("unexpected error:", err)
+			}
+
+			if _, want := Parse(tt.in); err != want {
+				t.Fatalf("unexpected error: %v, Parse returns %v", err, want)
+			}
+
 			if uid != Nil {
 				t.Fatal("unexpected nil uuid:", uid)
 			}
 		})
 	}
 }
+
+func BenchmarkParseBytes(b *testing.B) {
+	in := []byte(StaticUUID)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		if _, err := ParseBytes(in); err != nil {
+			b.Fatal("unexpected error:", err)
+		}
+	}
+}
+
+func BenchmarkParseBytes_Parse(b *testing.B) {
+	in := []byte(StaticUUID)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		if _, err := Parse(string(in)); err != nil {
+			b.Fatal("unexpected error:", err)
+		}
+	}
+}
+
+func TestParse_ErrorsIs(t *testing.T) {
+	_, err := Parse(strings.Repeat("a", 36))
+	if !errors.Is(err, ErrInvalidDashes) || !errors.Is(err, ErrFormat) || !errors.Is(err, ErrInvalidFormat) {
+		t.Fatal("unexpected error:", err)
+	}
+
+	if errors.Is(err, ErrLength) || errors.Is(ErrFormat, ErrInvalidDashes) {
+		t.Fatal("unexpected error:", err)
+	}
+
+	if _, err = Parse(""); !errors.Is(err, ErrInvalidLength) {
+		t.Fatal("unexpected error:", err)
+	}
+}
+
+func BenchmarkParse(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		if _, err := Parse(StaticUUID); err != nil {
+			b.Fatal("unexpected error:", err)
+		}
+	}
+}
+
+func BenchmarkParse_Errors(b *testing.B) {
+	table := []struct {
+		name string
+		in   string
+	}{
+		{"length", "12345678-1234-1234-1234-1234567890"},
+		{"format", strings.Repeat("a", 36)},
+		{"char", "12345678-1234-1234-1234-12345678901g"},
+	}
+
+	for _, tt := range table {
+		tt := tt
+		b.Run(tt.name, func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				if _, err := Parse(tt.in); err == nil {
+					b.Fatal("expected error, got nil")
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,96 @@
+package uuid
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+	"sync"
+)
+
+// V1Generator generates version 1 UUIDs, made of a 60-bit count of
+// 100-nanosecond intervals since 1582-10-15, a 14-bit clock sequence and a
+// 48-bit node identifier.
+//
+// The clock sequence is seeded randomly on the first UUID and incremented
+// whenever the clock doesn't move forward, so the UUIDs stay unique even if
+// the clock goes backward. It's safe for concurrent use.
+type V1Generator struct {
+	factory ReaderFactory
+
+	mu       sync.Mutex
+	node     [6]byte
+	hasNode  bool
+	seeded   bool
+	clockSeq uint16
+	last     uint64
+}
+
+// NewV1Generator creates a new instance of V1Generator with the given node
+// identifier, e.g. a MAC address, and the random number generator factory
+// used to seed the clock sequence. When node is empty a random node is drawn
+// from the factory on the first UUID, with the multicast bit set so it can't
+// clash with a real MAC address, as defined in RFC 4122 section 4.5. An error
+// is returned if node is neither empty nor 6 bytes long.
+func NewV1Generator(node []byte, factory ReaderFactory) (*V1Generator, error) {
+	v := &V1Generator{factory: factory}
+	switch len(node) {
+	case 0:
+	case len(v.node):
+		copy(v.node[:], node)
+		v.hasNode = true
+	default:
+		return nil, fmt.Errorf("uuid: invalid node length %d, expected 6", len(node))
+	}
+	return v, nil
+}
+
+// Concurrent returns true, V1Generator guards its state with a mutex.
+func (v *V1Generator) Concurrent() bool {
+	return true
+}
+
+// NewUUID generates a new UUID from the current time, the clock sequence and
+// the node, and sets the version and variant bits to satisfy the UUID v1
+// standard.
+func (v *V1Generator) NewUUID() (UUID, error) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	if !v.seeded {
+		if err := v.seed(); err != nil {
+			return Nil, err
+		}
+	}
+
+	ts := gregorianTimestamp(timeNow())
+	if ts <= v.last {
+		v.clockSeq = (v.clockSeq + 1) & 0x3fff
+	}
+	v.last = ts
+
+	var uid UUID
+	binary.BigEndian.PutUint32(uid[0:4], uint32(ts))
+	binary.BigEndian.PutUint16(uid[4:6], uint16(ts>>32))
+	binary.BigEndian.PutUint16(uid[6:8], uint16(ts>>48)|0x1000) // Version 1
+	uid[8] = 0x80 | byte(v.clockSeq>>8)                         // Variant is 10
+	uid[9] = byte(v.clockSeq)
+	copy(uid[10:], v.node[:])
+	return uid, nil
+}
+
+// seed draws the initial clock sequence, and the node when none was given,
+// from the factory. It must be called with v.mu held.
+func (v *V1Generator) seed() error {
+	var buf [8]byte
+	if _, err := io.ReadFull(v.factory(), buf[:]); err != nil {
+		return err
+	}
+
+	v.clockSeq = binary.BigEndian.Uint16(buf[0:2]) & 0x3fff
+	if !v.hasNode {
+		copy(v.node[:], buf[2:])
+		v.node[0] |= 0x01 // multicast bit
+	}
+	v.seeded = true
+	return nil
+}
@@ -0,0 +1,115 @@
+package uuid
+
+import (
+	"io"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestNewV1Generator(t *testing.T) {
+	now := time.Date(2024, time.May, 1, 12, 0, 0, 0, time.UTC)
+	pinClock(t, now)
+
+	v1, err := NewV1Generator(nil, StaticReader)
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	// the clock sequence and the random node (with the multicast bit) come
+	// from the static reader.
+	uid := must(t, v1.NewUUID)
+	if uid.String() != "55fd2000-07b2-11ef-8001-030304050607" {
+		t.Fatal("unexpected uuid:", uid)
+	}
+
+	if ts, ok := timestamp(uid); !ok || !ts.Equal(now) {
+		t.Fatal("unexpected timestamp:", ts)
+	}
+
+	// the clock not moving forward bumps the clock sequence.
+	if uid = must(t, v1.NewUUID); uid.String() != "55fd2000-07b2-11ef-8002-030304050607" {
+		t.Fatal("unexpected uuid:", uid)
+	}
+
+	timeNow = func() time.Time { return now.Add(-time.Second) }
+	if uid = must(t, v1.NewUUID); uid.String() != "55648980-07b2-11ef-8003-030304050607" {
+		t.Fatal("unexpected uuid:", uid)
+	}
+
+	// the clock moving forward keeps the clock sequence.
+	timeNow = func() time.Time { return now.Add(time.Microsecond) }
+	if uid = must(t, v1.NewUUID); uid.String() != "55fd200a-07b2-11ef-8003-030304050607" {
+		t.Fatal("unexpected uuid:", uid)
+	}
+}
+
+func TestNewV1Generator_Node(t *testing.T) {
+	node := []byte{0x00, 0x1b, 0x63, 0x84, 0x45, 0xe6}
+	v1, err := NewV1Generator(node, SecureReader)
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	node[0] = 0xff
+
+	uid := must(t, v1.NewUUID)
+	if got := uid.String()[24:]; got != "001b638445e6" {
+		t.Fatal("unexpected node:", got)
+	}
+
+	if uid[6]>>4 != 1 || uid[8]>>6 != 2 {
+		t.Fatal("unexpected version or variant:", uid)
+	}
+}
+
+func TestNewV1Generator_Errors(t *testing.T) {
+	for _, node := range [][]byte{{0x01}, make([]byte, 7)} {
+		if v1, err := NewV1Generator(node, SecureReader); err == nil || v1 != nil {
+			t.Fatalf("expected error for node length %d, got nil", len(node))
+		}
+	}
+
+	v1, err := NewV1Generator(nil, ErrorsReader)
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	if _, err := v1.NewUUID(); err != io.EOF {
+		t.Fatal("unexpected error:", err)
+	}
+}
+
+func TestNewV1Generator_Concurrent(t *testing.T) {
+	v1, err := NewV1Generator(nil, SecureReader)
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	const workers, n = 8, 1000
+	results := make(chan UUID, workers*n)
+	var wg sync.WaitGroup
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < n; j++ {
+				uid, err := v1.NewUUID()
+				if err != nil {
+					t.Error("unexpected error:", err)
+					return
+				}
+				results <- uid
+			}
+		}()
+	}
+	wg.Wait()
+	close(results)
+
+	seen := make(map[UUID]bool)
+	for uid := range results {
+		if seen[uid] {
+			t.Fatal("unexpected duplicated uuid:", uid)
+		}
+		seen[uid] = true
+	}
+}
@@ -0,0 +1,12 @@
+package uuid
+
+import "crypto/md5"
+
+// NewV3 returns the UUID v3 of the given name within the given namespace,
+// made of the MD5 hash of the namespace followed by the name. The same
+// namespace and name always give the same UUID, and the name is not
+// modified. Prefer NewV5 unless compatibility with existing v3 UUIDs is
+// needed.
+func NewV3(namespace UUID, name []byte) UUID {
+	return hashUUID(md5.New(), 3, namespace, name)
+}
@@ -0,0 +1,48 @@
+package uuid
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestNewV3(t *testing.T) {
+	table := []struct {
+		name      string
+		namespace UUID
+		in        string
+		want      string
+	}{
+		// the example of RFC 4122 appendix B, as corrected by erratum 1352.
+		{"rfc example", NamespaceDNS, "www.widgets.com", "3d813cbb-47fb-32ba-91df-831e1593ac29"},
+		{"url", NamespaceURL, "http://www.example.com/", "556cf76b-3b36-3ae6-85f9-50424b369b50"},
+		{"oid", NamespaceOID, "1.3.6.1", "dd1a1cef-13d5-368a-ad82-eca71acd4cd1"},
+		{"x500", NamespaceX500, "cn=John", "ac7afbb8-91ca-3a87-a172-39ff872d2659"},
+	}
+
+	for _, tt := range table {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			name := []byte(tt.in)
+			uid := NewV3(tt.namespace, name)
+			if uid.String() != tt.want {
+				t.Fatal("unexpected uuid:", uid)
+			}
+
+			if uid[6]>>4 != 3 || uid[8]>>6 != 2 {
+				t.Fatal("unexpected version or variant:", uid)
+			}
+
+			if !bytes.Equal(name, []byte(tt.in)) {
+				t.Fatal("unexpected modified name:", string(name))
+			}
+		})
+	}
+}
+
+func BenchmarkNewV3(b *testing.B) {
+	name := []byte("www.widgets.com")
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		NewV3(NamespaceDNS, name)
+	}
+}
@@ -0,0 +1,98 @@
+package uuid
+
+import (
+	"crypto/sha1"
+	"fmt"
+	"hash"
+)
+
+// The namespaces defined in RFC 4122 appendix C, to derive name-based UUIDs
+// from the name of a well-known kind.
+var (
+	// NamespaceDNS is the namespace of fully qualified domain names.
+	NamespaceDNS = UUID{0x6b, 0xa7, 0xb8, 0x10, 0x9d, 0xad, 0x11, 0xd1, 0x80, 0xb4, 0x00, 0xc0, 0x4f, 0xd4, 0x30, 0xc8}
+
+	// NamespaceURL is the namespace of URLs.
+	NamespaceURL = UUID{0x6b, 0xa7, 0xb8, 0x11, 0x9d, 0xad, 0x11, 0xd1, 0x80, 0xb4, 0x00, 0xc0, 0x4f, 0xd4, 0x30, 0xc8}
+
+	// NamespaceOID is the namespace of ISO object identifiers.
+	NamespaceOID = UUID{0x6b, 0xa7, 0xb8, 0x12, 0x9d, 0xad, 0x11, 0xd1, 0x80, 0xb4, 0x00, 0xc0, 0x4f, 0xd4, 0x30, 0xc8}
+
+	// NamespaceX500 is the namespace of X.500 distinguished names.
+	NamespaceX500 = UUID{0x6b, 0xa7, 0xb8, 0x14, 0x9d, 0xad, 0x11, 0xd1, 0x80, 0xb4, 0x00, 0xc0, 0x4f, 0xd4, 0x30, 0xc8}
+)
+
+// hashUUID returns the name-based UUID of the given version made of the
+// first 16 bytes of the hash of namespace followed by name, as defined in
+// RFC 4122 section 4.3.
+func hashUUID(h hash.Hash, version byte, namespace UUID, name []byte) UUID {
+	h.Write(namespace[:])
+	h.Write(name)
+
+	// sum is large enough for both MD5 and SHA-1, avoiding an allocation.
+	var sum [sha1.Size]byte
+	var uid UUID
+	copy(uid[:], h.Sum(sum[:0]))
+	uid[6] = (uid[6] & 0x0f) | version<<4
+	uid[8] = (uid[8] & 0x3f) | 0x80 // Variant is 10
+	return uid
+}
+
+// NewV5 returns the UUID v5 of the given name within the given namespace,
+// made of the SHA-1 hash of the namespace followed by the name. The same
+// namespace and name always give the same UUID.
+func NewV5(namespace UUID, name []byte) UUID {
+	return hashUUID(sha1.New(), 5, namespace, name)
+}
+
+// V5Generator generates the UUID v5 of a fixed namespace and name. It's
+// useful where a Generator is expected but the UUID must be deterministic.
+type V5Generator struct {
+	namespace UUID
+	name      []byte
+}
+
+// NewV5Generator creates a new instance of V5Generator with the given
+// namespace and name. The name is copied, so the caller can reuse it.
+func NewV5Generator(namespace UUID, name []byte) *V5Generator {
+	return &V5Generator{
+		namespace: namespace,
+		name:      append([]byte(nil), name...),
+	}
+}
+
+// Concurrent returns true, V5Generator holds no mutable state.
+func (v *V5Generator) Concurrent() bool {
+	return true
+}
+
+// NewUUID returns the UUID v5 of the namespace and the name, see NewV5. It
+// never fails.
+func (v *V5Generator) NewUUID() (UUID, error) {
+	return NewV5(v.namespace, v.name), nil
+}
+
+// Field returns a UUID v5 derived from the uuid as the namespace and the given
+// field name, e.g. the id of an entity's "address" sub-record. The same uuid
+// and name always give the same result, and different names give different
+// UUIDs, so related record ids can be computed instead of stored.
+func (id UUID) Field(name string) UUID {
+	return NewV5(id, []byte(name))
+}
+
+// Rederive returns the UUID v5 of name within newNamespace, to map the old
+// UUID v5 derived from oldNamespace and the same name when the namespace is
+// rotated. The old UUID and namespace are not checked, use RederiveVerified
+// to assert that old was derived from them.
+func Rederive(old UUID, oldNamespace, newNamespace UUID, name []byte) UUID {
+	return NewV5(newNamespace, name)
+}
+
+// RederiveVerified is like Rederive, but returns an error if old is not the
+// UUID v5 of name within oldNamespace.
+func RederiveVerified(old UUID, oldNamespace, newNamespace UUID, name []byte) (UUID, error) {
+	if NewV5(oldNamespace, name) != old {
+		return Nil, fmt.Errorf("uuid: %s is not derived from namespace %s and name %q", old, oldNamespace, name)
+	}
+	return Rederive(old, oldNamespace, newNamespace, name), nil
+}
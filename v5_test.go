@@ -0,0 +1,144 @@
+package uuid
+
+import "testing"
+
+func TestUUID_Field(t *testing.T) {
+	id := must(t, New)
+
+	a := id.Field("a")
+	if a != id.Field("a") {
+		t.Fatal("field uuid is not stable:", a)
+	}
+
+	if a == id.Field("b") || a == id {
+		t.Fatal("unexpected equal uuid:", a)
+	}
+
+	if other := must(t, New); a == other.Field("a") {
+		t.Fatal("unexpected equal uuid for another parent:", a)
+	}
+
+	if a[6]>>4 != 5 || a[8]>>6 != 2 {
+		t.Fatal("unexpected version or variant:", a)
+	}
+
+	// UUID v5 of the URL namespace and "a" computed independently.
+	ns := must(t, func() (UUID, error) { return Parse("6ba7b811-9dad-11d1-80b4-00c04fd430c8") })
+	if got := ns.Field("a"); got.String() != "e1407479-3136-56c0-9908-bb02fb0339e2" {
+		t.Fatal("unexpected uuid:", got)
+	}
+}
+
+func TestNamespaces(t *testing.T) {
+	table := []struct {
+		name string
+		ns   UUID
+		want string
+	}{
+		{"dns", NamespaceDNS, "6ba7b810-9dad-11d1-80b4-00c04fd430c8"},
+		{"url", NamespaceURL, "6ba7b811-9dad-11d1-80b4-00c04fd430c8"},
+		{"oid", NamespaceOID, "6ba7b812-9dad-11d1-80b4-00c04fd430c8"},
+		{"x500", NamespaceX500, "6ba7b814-9dad-11d1-80b4-00c04fd430c8"},
+	}
+
+	for _, tt := range table {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.ns.String(); got != tt.want {
+				t.Fatal("unexpected namespace:", got)
+			}
+		})
+	}
+}
+
+func TestNewV5(t *testing.T) {
+	table := []struct {
+		name      string
+		namespace UUID
+		in        string
+		want      string
+	}{
+		{"dns", NamespaceDNS, "www.example.com", "2ed6657d-e927-568b-95e1-2665a8aea6a2"},
+		{"url", NamespaceURL, "https://example.com/a", "6639460f-3425-5329-8097-a58f06127860"},
+		{"oid", NamespaceOID, "1.3.6.1", "1447fa61-5277-5fef-a9b3-fbc6e44f4af3"},
+		{"x500", NamespaceX500, "cn=John", "1713550e-4d56-5817-bce4-d5dac105f99d"},
+	}
+
+	for _, tt := range table {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			uid := NewV5(tt.namespace, []byte(tt.in))
+			if uid.String() != tt.want {
+				t.Fatal("unexpected uuid:", uid)
+			}
+
+			if uid[6]>>4 != 5 || uid[8]>>6 != 2 {
+				t.Fatal("unexpected version or variant:", uid)
+			}
+		})
+	}
+}
+
+func TestV5Generator(t *testing.T) {
+	name := []byte("www.example.com")
+	v5 := NewV5Generator(NamespaceDNS, name)
+	name[0] = 'x'
+
+	uid := must(t, v5.NewUUID)
+	if uid.String() != "2ed6657d-e927-568b-95e1-2665a8aea6a2" {
+		t.Fatal("unexpected uuid:", uid)
+	}
+
+	if again := must(t, v5.NewUUID); again != uid {
+		t.Fatal("unexpected uuid:", again)
+	}
+}
+
+func TestRederive(t *testing.T) {
+	name := []byte("https://example.com/a")
+	rotated := NewV5(NamespaceDNS, []byte("rotated"))
+	old := NewV5(NamespaceURL, name)
+
+	uid := Rederive(old, NamespaceURL, rotated, name)
+	if uid != NewV5(rotated, name) || uid == old {
+		t.Fatal("unexpected uuid:", uid)
+	}
+
+	verified, err := RederiveVerified(old, NamespaceURL, rotated, name)
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	if verified != uid {
+		t.Fatal("unexpected uuid:", verified)
+	}
+}
+
+func TestRederiveVerified_Errors(t *testing.T) {
+	name := []byte("https://example.com/a")
+	rotated := NewV5(NamespaceDNS, []byte("rotated"))
+	table := []struct {
+		name         string
+		old          UUID
+		oldNamespace UUID
+		in           []byte
+	}{
+		{"other namespace", NewV5(NamespaceURL, name), NamespaceDNS, name},
+		{"other name", NewV5(NamespaceURL, name), NamespaceURL, []byte("https://example.com/b")},
+		{"not derived", FromInt(1), NamespaceURL, name},
+	}
+
+	for _, tt := range table {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			uid, err := RederiveVerified(tt.old, tt.oldNamespace, rotated, tt.in)
+			if err == nil {
+				t.Fatal("expected error, got nil")
+			}
+
+			if uid != Nil {
+				t.Fatal("unexpected uuid:", uid)
+			}
+		})
+	}
+}
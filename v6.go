@@ -0,0 +1,88 @@
+package uuid
+
+import (
+	"encoding/binary"
+	"fmt"
+)
+
+// V6Generator generates version 6 UUIDs: the same fields as version 1, with
+// the timestamp reordered from the most to the least significant bits, so
+// they sort in creation order while keeping the node. It's safe for
+// concurrent use.
+type V6Generator struct {
+	v1 *V1Generator
+}
+
+// NewV6Generator creates a new instance of V6Generator with the given node
+// identifier and random number generator factory, see NewV1Generator.
+func NewV6Generator(node []byte, factory ReaderFactory) (*V6Generator, error) {
+	v1, err := NewV1Generator(node, factory)
+	if err != nil {
+		return nil, err
+	}
+	return &V6Generator{v1: v1}, nil
+}
+
+// Concurrent returns true, V6Generator guards its state with a mutex.
+func (v *V6Generator) Concurrent() bool {
+	return true
+}
+
+// NewUUID generates a new UUID from the current time, the clock sequence and
+// the node, and sets the version and variant bits to satisfy the UUID v6
+// standard.
+func (v *V6Generator) NewUUID() (UUID, error) {
+	uid, err := v.v1.NewUUID()
+	if err != nil {
+		return Nil, err
+	}
+
+	putV6Ticks(&uid, v1Ticks(uid))
+	return uid, nil
+}
+
+// defaultV6 is the generator used by NewV6, its random node is drawn from
+// rand.Reader.
+var defaultV6, _ = NewV6Generator(nil, SecureReader)
+
+// NewV6 generates a new UUID v6 with a random node using rand.Reader.
+func NewV6() (UUID, error) {
+	return defaultV6.NewUUID()
+}
+
+// ConvertV1ToV6 returns the UUID v6 with the same timestamp, clock sequence
+// and node as the given UUID v1. An error is returned if id is not a UUID v1.
+func ConvertV1ToV6(id UUID) (UUID, error) {
+	if id.Version() != 1 || id.Variant() != VariantRFC4122 {
+		return Nil, fmt.Errorf("uuid: %s is not a version 1 UUID", id)
+	}
+
+	putV6Ticks(&id, v1Ticks(id))
+	return id, nil
+}
+
+// v1Ticks returns the 60-bit Gregorian timestamp of a UUID v1, split into
+// the time_low, time_mid and time_hi fields.
+func v1Ticks(id UUID) uint64 {
+	low := uint64(binary.BigEndian.Uint32(id[0:4]))
+	mid := uint64(binary.BigEndian.Uint16(id[4:6]))
+	hi := uint64(binary.BigEndian.Uint16(id[6:8]) & 0x0fff)
+	return hi<<48 | mid<<32 | low
+}
+
+// v6Ticks returns the 60-bit Gregorian timestamp of a UUID v6, stored from
+// the most to the least significant bits.
+func v6Ticks(id UUID) uint64 {
+	hi := uint64(binary.BigEndian.Uint32(id[0:4]))
+	mid := uint64(binary.BigEndian.Uint16(id[4:6]))
+	low := uint64(binary.BigEndian.Uint16(id[6:8]) & 0x0fff)
+	return hi<<28 | mid<<12 | low
+}
+
+// putV6Ticks stores the 60-bit Gregorian timestamp ts in the first 8 bytes
+// of a UUID v6, with the version bits.
+func putV6Ticks(id *UUID, ts uint64) {
+	binary.BigEndian.PutUint32(id[0:4], uint32(ts>>28))
+	binary.BigEndian.PutUint16(id[4:6], uint16(ts>>12))
+	binary.BigEndian.PutUint16(id[6:8], uint16(ts&0x0fff)|0x6000) // Version 6
+}
@@ -0,0 +1,111 @@
+package uuid
+
+import (
+	"bytes"
+	"io"
+	"testing"
+	"time"
+)
+
+func TestNewV6Generator(t *testing.T) {
+	now := time.Date(2024, time.May, 1, 12, 0, 0, 0, time.UTC)
+	pinClock(t, now)
+
+	v6, err := NewV6Generator(nil, StaticReader)
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	uid := must(t, v6.NewUUID)
+	if uid.String() != "1ef07b25-5fd2-6000-8001-030304050607" {
+		t.Fatal("unexpected uuid:", uid)
+	}
+
+	if ts, ok := timestamp(uid); !ok || !ts.Equal(now) {
+		t.Fatal("unexpected timestamp:", ts)
+	}
+
+	// v6 sorts in creation order, unlike v1.
+	timeNow = func() time.Time { return now.Add(time.Hour) }
+	if later := must(t, v6.NewUUID); bytes.Compare(later[:], uid[:]) <= 0 {
+		t.Fatalf("uuid is not increasing: %s <= %s", later, uid)
+	}
+}
+
+func TestNewV6Generator_Errors(t *testing.T) {
+	if v6, err := NewV6Generator([]byte{0x01}, SecureReader); err == nil || v6 != nil {
+		t.Fatal("expected error, got nil")
+	}
+
+	v6, err := NewV6Generator(nil, ErrorsReader)
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	if _, err := v6.NewUUID(); err != io.EOF {
+		t.Fatal("unexpected error:", err)
+	}
+}
+
+func TestNewV6(t *testing.T) {
+	before := time.Now().Truncate(100 * time.Nanosecond)
+	uid := must(t, NewV6)
+	after := time.Now()
+
+	if uid[6]>>4 != 6 || uid[8]>>6 != 2 || uid[10]&0x01 != 1 {
+		t.Fatal("unexpected uuid:", uid)
+	}
+
+	ts, ok := timestamp(uid)
+	if !ok || ts.Before(before) || ts.After(after) {
+		t.Fatal("unexpected timestamp:", ts)
+	}
+
+	if other := must(t, NewV6); other == uid {
+		t.Fatal("unexpected equal uuid:", other)
+	}
+}
+
+func TestConvertV1ToV6(t *testing.T) {
+	v1 := must(t, func() (UUID, error) { return Parse("55fd2000-07b2-11ef-8000-000000000000") })
+	v6, err := ConvertV1ToV6(v1)
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	if v6.String() != "1ef07b25-5fd2-6000-8000-000000000000" {
+		t.Fatal("unexpected uuid:", v6)
+	}
+
+	g, err := NewV1Generator(nil, SecureReader)
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	// takes 100 random samples.
+	for i := 0; i < 100; i++ {
+		v1 = must(t, g.NewUUID)
+		v6 = must(t, func() (UUID, error) { return ConvertV1ToV6(v1) })
+
+		ts1, _ := timestamp(v1)
+		ts6, ok := timestamp(v6)
+		if !ok || !ts6.Equal(ts1) {
+			t.Fatalf("unexpected timestamp: %s, want %s", ts6, ts1)
+		}
+
+		if v6[6]>>4 != 6 || !bytes.Equal(v6[8:], v1[8:]) {
+			t.Fatal("unexpected uuid:", v6)
+		}
+	}
+}
+
+func TestConvertV1ToV6_Errors(t *testing.T) {
+	v1 := must(t, func() (UUID, error) { return Parse("55fd2000-07b2-11ef-8000-000000000000") })
+	v1[8] = 0xc0
+
+	for _, uid := range []UUID{Nil, must(t, New), must(t, NewV6), v1} {
+		if v6, err := ConvertV1ToV6(uid); err == nil || v6 != Nil {
+			t.Fatal("expected error, got nil for:", uid)
+		}
+	}
+}
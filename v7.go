@@ -0,0 +1,136 @@
+package uuid
+
+import (
+	"fmt"
+	"io"
+	"sync"
+	"time"
+)
+
+// maxV7Counter is the largest value of the 12-bit counter stored in the
+// rand_a field of a UUID v7.
+const maxV7Counter = 0xfff
+
+// V7Generator generates version 7 UUIDs, made of a 48-bit Unix millisecond
+// timestamp followed by random data, so they sort in creation order.
+//
+// Within the same millisecond the 12-bit rand_a field is used as a counter,
+// seeded randomly on each new millisecond and incremented for each UUID, so
+// the UUIDs of a generator are strictly increasing. When the counter would
+// overflow, or when the clock goes backward, the timestamp rolls forward
+// from the last one used. It's safe for concurrent use.
+type V7Generator struct {
+	factory ReaderFactory
+	clock   func() time.Time
+
+	mu      sync.Mutex
+	lastMs  uint64
+	counter uint16
+}
+
+// NewV7Generator creates a new instance of V7Generator with the given
+// random number generator factory.
+func NewV7Generator(factory ReaderFactory) *V7Generator {
+	return NewV7GeneratorWithClock(factory, time.Now)
+}
+
+// NewV7GeneratorWithClock creates a new instance of V7Generator with the
+// given random number generator factory, reading the current time from the
+// given clock. This is useful to pin the clock in tests.
+func NewV7GeneratorWithClock(factory ReaderFactory, clock func() time.Time) *V7Generator {
+	return &V7Generator{
+		factory: factory,
+		clock:   clock,
+	}
+}
+
+// Concurrent returns true, V7Generator guards its state with a mutex.
+func (v *V7Generator) Concurrent() bool {
+	return true
+}
+
+// NewUUID generates a new UUID by filling it with random data using the
+// factory, then setting the timestamp, the counter, and the version and
+// variant bits to satisfy the UUID v7 standard.
+func (v *V7Generator) NewUUID() (UUID, error) {
+	uid, err := fillUUID(v.factory())
+	if err != nil {
+		return Nil, err
+	}
+
+	ms := uint64(v.clock().UnixMilli())
+	v.mu.Lock()
+	v.next(&uid, ms)
+	v.mu.Unlock()
+	return uid, nil
+}
+
+// NewBatchOrdered generates n UUIDs with the random data read at once from
+// the factory. The batch is strictly increasing and sorts after all the UUIDs
+// previously generated by v.
+func (v *V7Generator) NewBatchOrdered(n int) ([]UUID, error) {
+	if n < 0 {
+		return nil, fmt.Errorf("uuid: negative batch size: %d", n)
+	}
+
+	if n == 0 {
+		return nil, nil
+	}
+
+	buf := make([]byte, 16*n)
+	if _, err := io.ReadFull(v.factory(), buf); err != nil {
+		return nil, err
+	}
+
+	uids := make([]UUID, n)
+	ms := uint64(v.clock().UnixMilli())
+	v.mu.Lock()
+	for i := range uids {
+		copy(uids[i][:], buf[i*16:])
+		v.next(&uids[i], ms)
+	}
+	v.mu.Unlock()
+	return uids, nil
+}
+
+// NewUUIDs implements BatchGenerator, it's NewBatchOrdered.
+func (v *V7Generator) NewUUIDs(n int) ([]UUID, error) {
+	return v.NewBatchOrdered(n)
+}
+
+// next sets the v7 fields of uid for the current time ms, advancing the
+// state of the generator. It must be called with v.mu held.
+func (v *V7Generator) next(uid *UUID, ms uint64) {
+	if ms > v.lastMs {
+		// the seed keeps its top bit clear, leaving room for at least 2048
+		// UUIDs within the millisecond.
+		v.lastMs = ms
+		v.counter = uint16(uid[6]&0x07)<<8 | uint16(uid[7])
+	} else {
+		v.counter++
+		if v.counter > maxV7Counter {
+			v.lastMs++
+			v.counter = 0
+		}
+	}
+
+	setV7Fields(uid, v.lastMs, v.counter)
+}
+
+// setV7Fields stores the millisecond timestamp and the 12-bit counter of a
+// UUID v7 and sets its version and variant bits, leaving the random rand_b
+// field untouched.
+func setV7Fields(uid *UUID, ms uint64, counter uint16) {
+	putUnixMillis(uid, ms)
+	uid[6] = 0x70 | byte(counter>>8)&0x0f // Version 7
+	uid[7] = byte(counter)
+	uid[8] = (uid[8] & 0x3f) | 0x80 // Variant is 10
+}
+
+// NewV7BatchOrdered generates n UUIDs v7 using rand.Reader, strictly
+// increasing even when they share the same millisecond, see
+// V7Generator.NewBatchOrdered. This is ideal to bulk insert rows in a known
+// order.
+func NewV7BatchOrdered(n int) ([]UUID, error) {
+	return NewV7GeneratorWithClock(SecureReader, timeNow).NewBatchOrdered(n)
+}
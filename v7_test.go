@@ -0,0 +1,188 @@
+package uuid
+
+import (
+	"bytes"
+	"io"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestNewV7BatchOrdered(t *testing.T) {
+	now := time.Date(2024, time.May, 1, 12, 0, 0, 0, time.UTC)
+	pinClock(t, now)
+
+	// more uuids than the 12-bit counter can hold within a millisecond.
+	uids, err := NewV7BatchOrdered(10000)
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	if len(uids) != 10000 {
+		t.Fatal("unexpected batch size:", len(uids))
+	}
+
+	first, _ := timestamp(uids[0])
+	if !first.Equal(now) {
+		t.Fatal("unexpected first timestamp:", first)
+	}
+
+	for i, uid := range uids {
+		if uid[6]>>4 != 7 || uid[8]>>6 != 2 {
+			t.Fatal("unexpected version or variant:", uid)
+		}
+
+		if i > 0 && bytes.Compare(uid[:], uids[i-1][:]) <= 0 {
+			t.Fatalf("uuid %d is not strictly increasing: %s <= %s", i, uid, uids[i-1])
+		}
+	}
+
+	// the timestamp rolled forward, one millisecond per counter overflow.
+	last, _ := timestamp(uids[len(uids)-1])
+	if d := last.Sub(now); d < 2*time.Millisecond || d > 3*time.Millisecond {
+		t.Fatal("unexpected last timestamp:", last)
+	}
+}
+
+func TestNewV7BatchOrdered_Overflow(t *testing.T) {
+	now := time.Date(2024, time.May, 1, 12, 0, 0, 0, time.UTC)
+
+	// the largest seed leaves room for 2049 uuids in the first millisecond.
+	reader := bytes.NewReader(bytes.Repeat([]byte{0xff}, 16*2050))
+	v7 := NewV7GeneratorWithClock(func() io.Reader { return reader }, func() time.Time { return now })
+	uids, err := v7.NewBatchOrdered(2050)
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	if ts, _ := timestamp(uids[2048]); !ts.Equal(now) {
+		t.Fatal("unexpected timestamp:", ts)
+	}
+
+	if uids[2048][6] != 0x7f || uids[2048][7] != 0xff {
+		t.Fatal("unexpected counter:", uids[2048])
+	}
+
+	if ts, _ := timestamp(uids[2049]); !ts.Equal(now.Add(time.Millisecond)) {
+		t.Fatal("unexpected rolled timestamp:", ts)
+	}
+
+	if uids[2049][6] != 0x70 || uids[2049][7] != 0x00 {
+		t.Fatal("unexpected reset counter:", uids[2049])
+	}
+}
+
+func TestNewV7BatchOrdered_Errors(t *testing.T) {
+	if _, err := NewV7BatchOrdered(-1); err == nil {
+		t.Fatal("expected error, got nil")
+	}
+
+	if uids, err := NewV7BatchOrdered(0); err != nil || len(uids) != 0 {
+		t.Fatal("unexpected result:", uids, err)
+	}
+
+	if _, err := NewV7Generator(ErrorsReader).NewBatchOrdered(1); err != io.EOF {
+		t.Fatal("unexpected error:", err)
+	}
+}
+
+func TestNewV7Generator(t *testing.T) {
+	v7 := NewV7Generator(SecureReader)
+
+	before := time.Now().Truncate(time.Millisecond)
+	uid := must(t, v7.NewUUID)
+	after := time.Now()
+
+	if !IsV7(uid) || uid[6]>>4 != 7 {
+		t.Fatal("unexpected uuid:", uid)
+	}
+
+	ts, ok := timestamp(uid)
+	if !ok || ts.Before(before) || ts.After(after) {
+		t.Fatal("unexpected timestamp:", ts)
+	}
+}
+
+func TestNewV7Generator_Clock(t *testing.T) {
+	now := time.Date(2024, time.May, 1, 12, 0, 0, 0, time.UTC)
+	v7 := NewV7GeneratorWithClock(SecureReader, func() time.Time { return now })
+
+	uid := must(t, v7.NewUUID)
+	if got := uid.String()[:13]; got != "018f3406-9e00" {
+		t.Fatal("unexpected timestamp bytes:", got)
+	}
+
+	// the same millisecond still sorts in creation order.
+	prev := uid
+	for i := 0; i < 10000; i++ {
+		uid = must(t, v7.NewUUID)
+		if bytes.Compare(uid[:], prev[:]) <= 0 {
+			t.Fatalf("uuid is not strictly increasing: %s <= %s", uid, prev)
+		}
+		prev = uid
+	}
+
+	// the clock going backward doesn't break the order.
+	now = now.Add(-time.Second)
+	uid = must(t, v7.NewUUID)
+	if bytes.Compare(uid[:], prev[:]) <= 0 {
+		t.Fatalf("uuid is not strictly increasing: %s <= %s", uid, prev)
+	}
+
+	// a new millisecond reseeds the counter.
+	now = now.Add(time.Hour)
+	uid = must(t, v7.NewUUID)
+	if ts, _ := timestamp(uid); !ts.Equal(now) {
+		t.Fatal("unexpected timestamp:", ts)
+	}
+
+	// the batch sorts after the previous uuids.
+	batch, err := v7.NewBatchOrdered(10)
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	if bytes.Compare(batch[0][:], uid[:]) <= 0 {
+		t.Fatalf("batch is not increasing: %s <= %s", batch[0], uid)
+	}
+}
+
+func TestNewV7Generator_Concurrent(t *testing.T) {
+	v7 := NewV7Generator(SecureReader)
+
+	const workers, n = 8, 1000
+	results := make(chan UUID, workers*n)
+	var wg sync.WaitGroup
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < n; j++ {
+				uid, err := v7.NewUUID()
+				if err != nil {
+					t.Error("unexpected error:", err)
+					return
+				}
+				results <- uid
+			}
+		}()
+	}
+	wg.Wait()
+	close(results)
+
+	seen := make(map[UUID]bool)
+	for uid := range results {
+		if seen[uid] {
+			t.Fatal("unexpected duplicated uuid:", uid)
+		}
+		seen[uid] = true
+	}
+}
+
+func TestNewV7Generator_ErrorsReader(t *testing.T) {
+	v7 := NewV7Generator(ErrorsReader)
+	_, err := v7.NewUUID()
+	if err != io.EOF {
+		t.Fatal("unexpected error:", err)
+	}
+}
@@ -0,0 +1,188 @@
+package uuid
+
+import (
+	"bytes"
+	"io"
+	"testing"
+	"time"
+)
+
+func TestNewV8(t *testing.T) {
+	data := [16]byte{0x00, 0x01, 0x02, 0x03, 0x04, 0x05, 0xf6, 0x07, 0xf8, 0x09, 0x0a, 0x0b, 0x0c, 0x0d, 0x0e, 0x0f}
+	if uid := NewV8(data); uid.String() != "00010203-0405-8607-b809-0a0b0c0d0e0f" {
+		t.Fatal("unexpected uuid:", uid)
+	}
+
+	// only the version and variant bits are changed.
+	reserved := UUID{6: 0xf0, 8: 0xc0}
+	for _, b := range []byte{0x00, 0xff} {
+		data = [16]byte{}
+		for i := range data {
+			data[i] = b
+		}
+
+		uid := NewV8(data)
+		if uid[6]>>4 != 8 || uid[8]>>6 != 2 {
+			t.Fatal("unexpected version or variant:", uid)
+		}
+
+		for i := range uid {
+			if uid[i]&^reserved[i] != data[i]&^reserved[i] {
+				t.Fatalf("unexpected changed byte %d: %s", i, uid)
+			}
+		}
+	}
+}
+
+func TestNewV8Nanos(t *testing.T) {
+	v8 := NewV8Nanos(SecureReader)
+
+	before := time.Now().UnixNano()
+	prev := must(t, v8.NewUUID)
+	after := time.Now().UnixNano()
+
+	ns, ok := prev.NanoTime()
+	if !ok {
+		t.Fatal("expected a nano time uuid:", prev)
+	}
+
+	if ns < before || ns > after {
+		t.Fatalf("unexpected nano time %d, want between %d and %d", ns, before, after)
+	}
+
+	if prev[6]>>4 != 8 || prev[8]>>6 != 2 {
+		t.Fatal("unexpected version or variant:", prev)
+	}
+
+	for i := 0; i < 1000; i++ {
+		uid := must(t, v8.NewUUID)
+		cur, _ := uid.NanoTime()
+		last, _ := prev.NanoTime()
+		if cur <= last {
+			t.Fatalf("nano time is not strictly increasing: %d <= %d", cur, last)
+		}
+
+		if bytes.Compare(uid[:], prev[:]) <= 0 {
+			t.Fatalf("uuid is not strictly increasing: %s <= %s", uid, prev)
+		}
+		prev = uid
+	}
+}
+
+func TestNewV8Nanos_ErrorsReader(t *testing.T) {
+	v8 := NewV8Nanos(ErrorsReader)
+	_, err := v8.NewUUID()
+	if err != io.EOF {
+		t.Fatal("unexpected error:", err)
+	}
+}
+
+func TestUUID_NanoTime(t *testing.T) {
+	uid := must(t, New)
+	if _, ok := uid.NanoTime(); ok {
+		t.Fatal("unexpected nano time for a v4 uuid:", uid)
+	}
+}
+
+func TestNewV8SeriesGenerator(t *testing.T) {
+	now := time.Date(2024, time.May, 1, 12, 0, 0, 0, time.UTC)
+	pinClock(t, now)
+
+	cpu := NewV8SeriesGenerator([]byte("cpu"), SecureReader)
+	var prev UUID
+	for i := 0; i < 100; i++ {
+		pinClock(t, now.Add(time.Duration(i)*time.Millisecond))
+		uid := must(t, cpu.NewUUID)
+
+		if uid[6]>>4 != 8 || uid[8]>>6 != 2 {
+			t.Fatal("unexpected version or variant:", uid)
+		}
+
+		ts, ok := uid.SeriesTime()
+		if !ok || !ts.Equal(timeNow()) {
+			t.Fatal("unexpected series time:", ts)
+		}
+
+		// within a shard the uuids are ordered by time.
+		if i > 0 && bytes.Compare(uid[:], prev[:]) <= 0 {
+			t.Fatalf("uuid is not increasing: %s <= %s", uid, prev)
+		}
+		prev = uid
+	}
+
+	// the prefix byte is stable per series.
+	a := must(t, cpu.NewUUID)
+	b := must(t, NewV8SeriesGenerator([]byte("cpu"), SecureReader).NewUUID)
+	ha, _ := a.SeriesHash()
+	hb, _ := b.SeriesHash()
+	if ha != hb || ha != byte(hash64([]byte("cpu"))) {
+		t.Fatal("unexpected series hash:", ha, hb)
+	}
+
+	m := must(t, NewV8SeriesGenerator([]byte("memory"), SecureReader).NewUUID)
+	if hm, _ := m.SeriesHash(); hm == ha {
+		t.Fatal("unexpected equal series hash:", hm)
+	}
+}
+
+func TestNewV8SeriesGenerator_ErrorsReader(t *testing.T) {
+	v8 := NewV8SeriesGenerator([]byte("cpu"), ErrorsReader)
+	_, err := v8.NewUUID()
+	if err != io.EOF {
+		t.Fatal("unexpected error:", err)
+	}
+}
+
+func TestUUID_SeriesHash(t *testing.T) {
+	uid := must(t, New)
+	if _, ok := uid.SeriesHash(); ok {
+		t.Fatal("unexpected series hash for a v4 uuid:", uid)
+	}
+
+	if _, ok := uid.SeriesTime(); ok {
+		t.Fatal("unexpected series time for a v4 uuid:", uid)
+	}
+}
+
+func TestNewV8WithTrace(t *testing.T) {
+	traceID := [8]byte{0x4b, 0xf9, 0x2f, 0x35, 0x77, 0xb3, 0x4d, 0xa6}
+	uid := must(t, func() (UUID, error) { return NewV8WithTrace(traceID, SecureReader) })
+	if uid[6]>>4 != 8 || uid[8]>>6 != 2 {
+		t.Fatal("unexpected version or variant:", uid)
+	}
+
+	got, ok := uid.TraceID()
+	if !ok || got != traceID {
+		t.Fatalf("unexpected trace id: %x", got)
+	}
+
+	// the rest is random.
+	if other := must(t, func() (UUID, error) { return NewV8WithTrace(traceID, SecureReader) }); other == uid {
+		t.Fatal("unexpected equal uuid:", other)
+	}
+
+	// takes 100 random samples.
+	for i := 0; i < 100; i++ {
+		if _, err := io.ReadFull(SecureReader(), traceID[:]); err != nil {
+			t.Fatal("unexpected error:", err)
+		}
+
+		uid = must(t, func() (UUID, error) { return NewV8WithTrace(traceID, SecureReader) })
+		if got, _ := uid.TraceID(); got != traceID {
+			t.Fatalf("unexpected trace id: %x, want %x", got, traceID)
+		}
+	}
+}
+
+func TestNewV8WithTrace_ErrorsReader(t *testing.T) {
+	_, err := NewV8WithTrace([8]byte{}, ErrorsReader)
+	if err != io.EOF {
+		t.Fatal("unexpected error:", err)
+	}
+}
+
+func TestUUID_TraceID(t *testing.T) {
+	if _, ok := must(t, New).TraceID(); ok {
+		t.Fatal("v4 uuid should have no trace id")
+	}
+}